@@ -0,0 +1,132 @@
+package nes
+
+import "fmt"
+
+// maximum number of devices connected to a SystemBus
+const maxDeviceCount = 255
+
+// Device is a component connected to the bus, which answers to the
+// addresses from Start to End (inclusive). The address is translated to an
+// offset from Start and then masked with Mask before it's passed to the
+// handlers, so a device that doesn't decode all address lines is mirrored
+// over its range. A nil Read leaves the reads unanswered (e.g. write-only
+// registers) and a nil Write ignores the writes (e.g. ROM). Peek is used
+// instead of Read when the bus is only inspected, so a device whose reads
+// have side effects (e.g. a register cleared when it's read) can avoid them;
+// when it's nil, Read is used.
+//
+// The bits set in Undriven aren't driven by the device when it's read, so
+// they keep the last value on the data bus (open bus).
+type Device struct {
+	Name     string
+	Start    uint16
+	End      uint16
+	Mask     uint16
+	Undriven uint8
+	Read     func(offset uint16) uint8
+	Write    func(offset uint16, value uint8)
+	Peek     func(offset uint16) uint8
+}
+
+// Bus connects the CPU to the other components of the system.
+type Bus interface {
+	ReadByte(address uint16) uint8
+	WriteByte(address uint16, value uint8)
+	// Peek reads from address without affecting the data bus.
+	Peek(address uint16) uint8
+	// DataBus returns the last value driven on the data bus.
+	DataBus() uint8
+	Register(device Device) error
+}
+
+// SystemBus is a Bus which dispatches every access to the device registered
+// for its address. When no device answers to a read, the value returned is
+// the last one driven on the data bus, which is kept by the bus capacitance.
+type SystemBus struct {
+	// last value read or written
+	dataBus uint8
+
+	devices []Device
+	// index+1 in devices of the device answering each address; 0 means
+	// unmapped
+	decoder [MemorySize]uint8
+}
+
+func NewSystemBus() *SystemBus {
+	return &SystemBus{}
+}
+
+// Register connects device to the bus. A device registered later takes
+// precedence over the ones already registered in the same addresses, so a
+// component can be replaced (e.g. in tests).
+func (bus *SystemBus) Register(device Device) error {
+	if device.Start > device.End {
+		return fmt.Errorf("invalid address range for device %v: $%04X-$%04X", device.Name, device.Start, device.End)
+	}
+
+	if len(bus.devices) == maxDeviceCount {
+		return fmt.Errorf("too many devices connected to the bus; cannot register %v", device.Name)
+	}
+
+	bus.devices = append(bus.devices, device)
+	index := uint8(len(bus.devices))
+
+	for address := int(device.Start); address <= int(device.End); address++ {
+		bus.decoder[address] = index
+	}
+
+	return nil
+}
+
+// decode finds the device answering to address and the offset passed to
+// it. It's used by every access, so reads and writes are mirrored alike.
+func (bus *SystemBus) decode(address uint16) (*Device, uint16) {
+	index := bus.decoder[address]
+	if index == 0 {
+		return nil, 0
+	}
+
+	device := &bus.devices[index-1]
+
+	return device, (address - device.Start) & device.Mask
+}
+
+func (bus *SystemBus) ReadByte(address uint16) uint8 {
+	bus.dataBus = bus.read(address, false)
+	return bus.dataBus
+}
+
+func (bus *SystemBus) Peek(address uint16) uint8 {
+	return bus.read(address, true)
+}
+
+// read returns the value driven on the data bus by a read from address,
+// which is only inspected when peek is set.
+func (bus *SystemBus) read(address uint16, peek bool) uint8 {
+	device, offset := bus.decode(address)
+	if device == nil || device.Read == nil {
+		return bus.dataBus
+	}
+
+	read := device.Read
+	if peek && device.Peek != nil {
+		read = device.Peek
+	}
+
+	return read(offset)&^device.Undriven | bus.dataBus&device.Undriven
+}
+
+func (bus *SystemBus) DataBus() uint8 {
+	return bus.dataBus
+}
+
+func (bus *SystemBus) WriteByte(address uint16, value uint8) {
+	bus.dataBus = value
+
+	device, offset := bus.decode(address)
+	if device == nil || device.Write == nil {
+		return
+	}
+
+	device.Write(offset, value)
+}
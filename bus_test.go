@@ -0,0 +1,124 @@
+package nes
+
+import "testing"
+
+// newFlatTestBus creates a bus with plain memory in all addresses.
+func newFlatTestBus() *SystemBus {
+	memory := NewMemory(MemorySize)
+
+	bus := NewSystemBus()
+	bus.Register(Device{Name: "memory", Start: 0x0000, End: 0xFFFF, Mask: 0xFFFF, Read: memory.ReadByte, Write: memory.WriteByte})
+
+	return bus
+}
+
+func TestSystemBus_Register(t *testing.T) {
+	bus := NewSystemBus()
+	memory := NewMemory(0x10)
+
+	if err := bus.Register(Device{Name: "memory", Start: 0x1000, End: 0x1FFF, Mask: 0x000F, Read: memory.ReadByte, Write: memory.WriteByte}); err != nil {
+		t.Fatal(err)
+	}
+
+	bus.WriteByte(0x1003, 0x12)
+
+	if value := memory.ReadByte(0x03); value != 0x12 {
+		t.Errorf("unexpected value written to the device; got=%02X, want=%02X", value, 0x12)
+	}
+	if value := bus.ReadByte(0x1FF3); value != 0x12 {
+		t.Errorf("unexpected value read from the mirror; got=%02X, want=%02X", value, 0x12)
+	}
+
+	bus.WriteByte(0x2003, 0x34)
+
+	if value := memory.ReadByte(0x03); value != 0x12 {
+		t.Errorf("a write to an unmapped address should be ignored; got=%02X, want=%02X", value, 0x12)
+	}
+}
+
+func TestSystemBus_RegisterOverride(t *testing.T) {
+	bus := NewSystemBus()
+	memory := NewMemory(0x100)
+	rom := Memory{0xAB}
+
+	bus.Register(Device{Name: "memory", Start: 0x0000, End: 0x00FF, Mask: 0x00FF, Read: memory.ReadByte, Write: memory.WriteByte})
+	bus.Register(Device{Name: "ROM", Start: 0x0080, End: 0x0080, Read: rom.ReadByte})
+
+	bus.WriteByte(0x0080, 0x12)
+	bus.WriteByte(0x0081, 0x34)
+
+	if value := bus.ReadByte(0x0080); value != 0xAB {
+		t.Errorf("unexpected value read from the device registered later; got=%02X, want=%02X", value, 0xAB)
+	}
+	if value := memory.ReadByte(0x80); value != 0x00 {
+		t.Errorf("the device registered first should not answer to the overridden address; got=%02X, want=%02X", value, 0x00)
+	}
+	if value := bus.ReadByte(0x0081); value != 0x34 {
+		t.Errorf("unexpected value read from the device registered first; got=%02X, want=%02X", value, 0x34)
+	}
+}
+
+func TestSystemBus_OpenBus(t *testing.T) {
+	bus := NewSystemBus()
+	memory := Memory{0x0F, 0x00}
+
+	bus.Register(Device{Name: "register", Start: 0x0000, End: 0x0000, Undriven: 0xF0, Read: memory.ReadByte})
+	bus.Register(Device{Name: "memory", Start: 0x0001, End: 0x0001, Read: memory[1:].ReadByte, Write: memory[1:].WriteByte})
+
+	bus.WriteByte(0x0001, 0xA5)
+
+	if value := bus.ReadByte(0x0002); value != 0xA5 {
+		t.Errorf("unexpected value read from an unmapped address after a write; got=%02X, want=%02X", value, 0xA5)
+	}
+	if value := bus.ReadByte(0x0000); value != 0xAF {
+		t.Errorf("unexpected value read from a partially decoded register; got=%02X, want=%02X", value, 0xAF)
+	}
+	if value := bus.ReadByte(0x0002); value != 0xAF {
+		t.Errorf("unexpected value read from an unmapped address after a read; got=%02X, want=%02X", value, 0xAF)
+	}
+
+	memory[1] = 0x33
+
+	if value := bus.Peek(0x0001); value != 0x33 {
+		t.Errorf("unexpected value peeked; got=%02X, want=%02X", value, 0x33)
+	}
+	if value := bus.ReadByte(0x0002); value != 0xAF {
+		t.Errorf("peeking should not change the data bus; got=%02X, want=%02X", value, 0xAF)
+	}
+}
+
+func TestSystemBus_Peek(t *testing.T) {
+	bus := NewSystemBus()
+	reads := 0
+
+	bus.Register(Device{
+		Name:  "register",
+		Start: 0x0000,
+		End:   0x0000,
+		Read:  func(uint16) uint8 { reads++; return 0x12 },
+		Peek:  func(uint16) uint8 { return 0x12 },
+	})
+
+	if value := bus.Peek(0x0000); value != 0x12 || reads != 0 {
+		t.Errorf("peeking should not read the device; got=%02X after %v read(s), want=%02X after 0 reads", value, reads, 0x12)
+	}
+	if value := bus.ReadByte(0x0000); value != 0x12 || reads != 1 {
+		t.Errorf("unexpected read from the device; got=%02X after %v read(s), want=%02X after 1 read", value, reads, 0x12)
+	}
+}
+
+func TestSystemBus_RegisterInvalidRange(t *testing.T) {
+	bus := NewSystemBus()
+
+	if err := bus.Register(Device{Name: "invalid", Start: 0x2000, End: 0x1FFF}); err == nil {
+		t.Error("a device with an invalid address range should not be registered")
+	}
+}
+
+func BenchmarkSystemBus_ReadByte(b *testing.B) {
+	bus := newFlatTestBus()
+
+	for n := 0; n < b.N; n++ {
+		_ = bus.ReadByte(0x1234)
+	}
+}
@@ -0,0 +1,165 @@
+package nes
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/cd1/nes-emulator/mapper"
+)
+
+// Cartridge is the game inserted in the system: its memories connected
+// through the mapper of its board.
+type Cartridge struct {
+	Mapper mapper.Mapper
+
+	board   mapper.Board
+	battery bool
+	trainer []uint8
+	// RAM for the nametables not in the CIRAM, in four-screen boards
+	vram []uint8
+	// battery-backed RAM as it was last loaded or saved
+	saved []uint8
+}
+
+// NewCartridge builds the board described by the header of game and
+// connects it to its mapper.
+func NewCartridge(game Game) (*Cartridge, error) {
+	header := game.Header
+
+	cart := &Cartridge{
+		board: mapper.Board{
+			PRG:       game.PRG,
+			CHR:       game.CHR,
+			PRGRAM:    make([]uint8, header.PRGRAMBytes()+header.PRGNVRAMBytes()),
+			Mirroring: header.Mirroring(),
+			Submapper: header.Submapper(),
+		},
+		battery: header.HasBattery(),
+		trainer: game.Trainer,
+	}
+
+	if header.Mirroring() == mapper.MirroringFourScreen {
+		cart.vram = make([]uint8, CIRAMSize)
+	}
+
+	if len(cart.trainer) > 0 && len(cart.board.PRGRAM) < PRGRAMSize {
+		// the trainer needs the PRG RAM even when the header doesn't have it
+		cart.board.PRGRAM = make([]uint8, PRGRAMSize)
+	}
+
+	if len(cart.board.CHR) == 0 {
+		size := header.CHRRAMBytes() + header.CHRNVRAMBytes()
+		if size == 0 {
+			// some NES 2.0 headers don't declare the CHR RAM
+			size = CHRBankSize
+		}
+
+		cart.board.CHR = make([]uint8, size)
+		cart.board.CHRRAM = true
+	}
+
+	cart.loadTrainer()
+
+	var err error
+
+	if cart.Mapper, err = mapper.New(header.MapperNumber(), &cart.board); err != nil {
+		return nil, err
+	}
+
+	return cart, nil
+}
+
+// HasBattery checks whether the cartridge keeps its PRG RAM when the
+// system is turned off.
+func (cart *Cartridge) HasBattery() bool {
+	return cart.battery && len(cart.board.PRGRAM) > 0
+}
+
+// LoadBattery reads the battery-backed RAM from r. An empty save leaves the
+// RAM as the game would find it the first time it's run; a save smaller than
+// the RAM is refused with TruncatedSaveError.
+func (cart *Cartridge) LoadBattery(r io.Reader) error {
+	if !cart.HasBattery() {
+		return nil
+	}
+
+	n, err := io.ReadFull(r, cart.board.PRGRAM)
+	switch err {
+	case nil, io.EOF:
+	case io.ErrUnexpectedEOF:
+		return TruncatedSaveError{len(cart.board.PRGRAM), n}
+	default:
+		return err
+	}
+
+	// the trainer is always loaded on top of the save, which is only
+	// written again when the game changes the RAM
+	cart.loadTrainer()
+	cart.saved = append(cart.saved[:0], cart.board.PRGRAM...)
+
+	return nil
+}
+
+// loadTrainer copies the trainer into $7000-$71FF, where it's expected by
+// the patched games.
+func (cart *Cartridge) loadTrainer() {
+	if len(cart.trainer) > 0 {
+		copy(cart.board.PRGRAM[TrainerStart-PRGRAMStart:], cart.trainer)
+	}
+}
+
+// SaveBattery writes the battery-backed RAM to w, all at once, if it has
+// changed since it was last loaded or saved.
+func (cart *Cartridge) SaveBattery(w io.Writer) error {
+	if !cart.HasBattery() || bytes.Equal(cart.saved, cart.board.PRGRAM) {
+		return nil
+	}
+
+	if _, err := w.Write(cart.board.PRGRAM); err != nil {
+		return err
+	}
+
+	cart.saved = append(cart.saved[:0], cart.board.PRGRAM...)
+
+	return nil
+}
+
+// ReadCPU reads from address, in $4020-$FFFF. When the cartridge doesn't
+// drive the data bus in address, false is returned.
+func (cart *Cartridge) ReadCPU(address uint16) (uint8, bool) {
+	return cart.Mapper.ReadCPU(address)
+}
+
+func (cart *Cartridge) WriteCPU(address uint16, value uint8) {
+	cart.Mapper.WriteCPU(address, value)
+}
+
+// ReadPPU reads from address, in $0000-$1FFF.
+func (cart *Cartridge) ReadPPU(address uint16) uint8 {
+	return cart.Mapper.ReadPPU(address)
+}
+
+func (cart *Cartridge) WritePPU(address uint16, value uint8) {
+	cart.Mapper.WritePPU(address, value)
+}
+
+// device returns the cartridge as a bus device in $4020-$FFFF. bus is used
+// to return the open bus value when the cartridge doesn't drive it.
+func (cart *Cartridge) device(bus Bus) Device {
+	return Device{
+		Name:  "cartridge",
+		Start: CartridgeStart,
+		End:   CartridgeEnd,
+		Mask:  0xFFFF,
+		Read: func(offset uint16) uint8 {
+			if value, ok := cart.ReadCPU(CartridgeStart + offset); ok {
+				return value
+			}
+
+			return bus.DataBus()
+		},
+		Write: func(offset uint16, value uint8) {
+			cart.WriteCPU(CartridgeStart+offset, value)
+		},
+	}
+}
@@ -0,0 +1,273 @@
+package nes
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cd1/nes-emulator/mapper"
+)
+
+func TestNewCartridge(t *testing.T) {
+	game := newTestGame(t, 1, 0)
+	// vertical mirroring
+	game.Header[6] = 0x01
+
+	cart, err := NewCartridge(*game)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mirroring := cart.Mapper.Mirroring(); mirroring != mapper.MirroringVertical {
+		t.Errorf("unexpected mirroring; got=%v, want=%v", mirroring, mapper.MirroringVertical)
+	}
+
+	// without CHR ROM, the cartridge has CHR RAM
+	cart.WritePPU(0x1FFF, 0x12)
+
+	if value := cart.ReadPPU(0x1FFF); value != 0x12 {
+		t.Errorf("unexpected value read from the CHR RAM; got=%02X, want=%02X", value, 0x12)
+	}
+}
+
+func TestNewCartridge_UnsupportedMapper(t *testing.T) {
+	game := newTestGame(t, 1, 0)
+	// mapper 0xFF
+	game.Header[6] = 0xF0
+	game.Header[7] = 0xF0
+
+	if _, err := NewCartridge(*game); err != (mapper.UnsupportedMapperError{Number: 0xFF}) {
+		t.Errorf("unexpected error; got=%v, want=%v", err, mapper.UnsupportedMapperError{Number: 0xFF})
+	}
+}
+
+// newTestMapperGame builds a game for mapper in the format read by LoadGame.
+// A submapper other than 0 makes it a NES 2.0 header. The first byte of each
+// PRG bank (16 kiB) holds its number and the others hold 0xFF, so they don't
+// cause bus conflicts; each CHR bank (8 kiB) is filled with 0x80 plus its
+// number.
+func newTestMapperGame(t testing.TB, number uint8, submapper uint8, prgBankCount uint8, chrBankCount uint8) *Game {
+	header := make([]uint8, GameHeaderSize)
+	copy(header, NESMagicNumber)
+	header[4] = prgBankCount
+	header[5] = chrBankCount
+	header[6] = number << 4
+	header[7] = number & 0xF0
+
+	if submapper != 0 {
+		header[7] |= 0x08
+		header[8] = submapper << 4
+	}
+
+	prg := make([]uint8, int(prgBankCount)*PRGBankSize)
+	for i := range prg {
+		if i%PRGBankSize == 0 {
+			prg[i] = uint8(i / PRGBankSize)
+		} else {
+			prg[i] = 0xFF
+		}
+	}
+
+	chr := make([]uint8, int(chrBankCount)*CHRBankSize)
+	for i := range chr {
+		chr[i] = 0x80 + uint8(i/CHRBankSize)
+	}
+
+	data := append(append(header, prg...), chr...)
+
+	game, err := LoadGame(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return game
+}
+
+func newTestMapperNES(t testing.TB, game *Game) *NES {
+	var system NES
+
+	if _, err := system.PowerOn(*game); err != nil {
+		t.Fatal(err)
+	}
+
+	return &system
+}
+
+func TestCartridge_UxROM(t *testing.T) {
+	tests := []struct {
+		submapper uint8
+		want      uint8
+	}{
+		// the ROM drives 0 in $8000, so nothing is switched
+		{0, 0},
+		{2, 0},
+		{1, 6},
+	}
+
+	for _, test := range tests {
+		system := newTestMapperNES(t, newTestMapperGame(t, 2, test.submapper, 8, 0))
+
+		system.WriteByte(0x8000, 0x06)
+
+		if bank := system.ReadByte(0x8000); bank != test.want {
+			t.Errorf("unexpected bank in $8000 after a bus conflict with submapper %v; got=%v, want=%v", test.submapper, bank, test.want)
+		}
+
+		system.WriteByte(0x8001, 0x03)
+
+		if bank := system.ReadByte(0x8000); bank != 3 {
+			t.Errorf("unexpected bank in $8000 with submapper %v; got=%v, want=%v", test.submapper, bank, 3)
+		}
+		if bank := system.ReadByte(0xC000); bank != 7 {
+			t.Errorf("unexpected bank in $C000 with submapper %v; got=%v, want=%v", test.submapper, bank, 7)
+		}
+	}
+}
+
+func TestCartridge_CNROM(t *testing.T) {
+	system := newTestMapperNES(t, newTestMapperGame(t, 3, 0, 1, 4))
+
+	system.WriteByte(0xC001, 0x02)
+
+	if bank := system.Cartridge.ReadPPU(0x1FFF); bank != 0x82 {
+		t.Errorf("unexpected CHR bank; got=%02X, want=%02X", bank, 0x82)
+	}
+	if bank := system.ReadByte(0xC000); bank != 0 {
+		t.Errorf("unexpected bank in $C000; got=%v, want=%v", bank, 0)
+	}
+}
+
+func TestCartridge_AxROM(t *testing.T) {
+	system := newTestMapperNES(t, newTestMapperGame(t, 7, 0, 8, 0))
+
+	// ANROM and AOROM can't be told apart, so the bus conflicts are ignored
+	system.WriteByte(0x8000, 0x12)
+
+	if bank := system.ReadByte(0x8000); bank != 4 {
+		t.Errorf("unexpected bank in $8000; got=%v, want=%v", bank, 4)
+	}
+	if bank := system.ReadByte(0xC000); bank != 5 {
+		t.Errorf("unexpected bank in $C000; got=%v, want=%v", bank, 5)
+	}
+	if mirroring := system.Cartridge.Mapper.Mirroring(); mirroring != mapper.MirroringSingleScreenUpper {
+		t.Errorf("unexpected mirroring; got=%v, want=%v", mirroring, mapper.MirroringSingleScreenUpper)
+	}
+
+	system.WriteByte(0x8001, 0x01)
+
+	if mirroring := system.Cartridge.Mapper.Mirroring(); mirroring != mapper.MirroringSingleScreenLower {
+		t.Errorf("unexpected mirroring; got=%v, want=%v", mirroring, mapper.MirroringSingleScreenLower)
+	}
+}
+
+func TestCartridge_GxROM(t *testing.T) {
+	system := newTestMapperNES(t, newTestMapperGame(t, 66, 0, 8, 4))
+
+	system.WriteByte(0x8001, 0x21)
+
+	if bank := system.ReadByte(0x8000); bank != 4 {
+		t.Errorf("unexpected bank in $8000; got=%v, want=%v", bank, 4)
+	}
+	if bank := system.Cartridge.ReadPPU(0x0000); bank != 0x81 {
+		t.Errorf("unexpected CHR bank; got=%02X, want=%02X", bank, 0x81)
+	}
+
+	// the ROM drives 4 in $8000
+	system.WriteByte(0x8000, 0x13)
+
+	if bank := system.ReadByte(0x8000); bank != 0 {
+		t.Errorf("unexpected bank in $8000 after a bus conflict; got=%v, want=%v", bank, 0)
+	}
+	if bank := system.Cartridge.ReadPPU(0x0000); bank != 0x80 {
+		t.Errorf("unexpected CHR bank after a bus conflict; got=%02X, want=%02X", bank, 0x80)
+	}
+}
+
+func TestCartridge_ColorDreams(t *testing.T) {
+	system := newTestMapperNES(t, newTestMapperGame(t, 11, 0, 8, 16))
+
+	system.WriteByte(0xFFF0, 0x31)
+
+	if bank := system.ReadByte(0x8000); bank != 2 {
+		t.Errorf("unexpected bank in $8000; got=%v, want=%v", bank, 2)
+	}
+	if bank := system.Cartridge.ReadPPU(0x0000); bank != 0x83 {
+		t.Errorf("unexpected CHR bank; got=%02X, want=%02X", bank, 0x83)
+	}
+}
+
+func TestCartridge_Trainer(t *testing.T) {
+	header := make([]uint8, GameHeaderSize)
+	copy(header, NESMagicNumber)
+	header[4] = 1
+	// trainer
+	header[6] = 0x04
+
+	// LDA $7100; STA $00; JMP $7005
+	trainer := make([]uint8, TrainerSize)
+	copy(trainer, []uint8{0xAD, 0x00, 0x71, 0x85, 0x00, 0x4C, 0x05, 0x70})
+	trainer[0x100] = 0x42
+
+	prg := make([]uint8, PRGBankSize)
+	// reset vector
+	prg[0x3FFC] = 0x00
+	prg[0x3FFD] = 0x70
+
+	data := append(append(header, trainer...), prg...)
+
+	game, err := LoadGame(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	system := newTestMapperNES(t, game)
+
+	for i := 0; i < 3; i++ {
+		if _, err := system.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if value := system.ReadByte(0x0000); value != 0x42 {
+		t.Errorf("unexpected value stored by the trainer; got=%02X, want=%02X", value, 0x42)
+	}
+	if pc := system.CPU.ProgramCounter; pc != 0x7005 {
+		t.Errorf("unexpected PC after running the trainer; got=%04X, want=%04X", pc, 0x7005)
+	}
+}
+
+func TestCartridge_TrainerBattery(t *testing.T) {
+	game := newTestGame(t, 1, 0)
+	// battery
+	game.Header[6] = 0x02
+	game.Trainer = []uint8{0x12, 0x34}
+
+	cart, err := NewCartridge(*game)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	save := make([]uint8, PRGRAMSize)
+	for i := range save {
+		save[i] = 0xFF
+	}
+
+	if err := cart.LoadBattery(bytes.NewReader(save)); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, _ := cart.ReadCPU(TrainerStart + 1); value != 0x34 {
+		t.Errorf("the trainer should be loaded on top of the save; got=%02X, want=%02X", value, 0x34)
+	}
+	if value, _ := cart.ReadCPU(TrainerEnd + 1); value != 0xFF {
+		t.Errorf("unexpected value loaded from the save; got=%02X, want=%02X", value, 0xFF)
+	}
+
+	var saved bytes.Buffer
+
+	if err := cart.SaveBattery(&saved); err != nil {
+		t.Fatal(err)
+	}
+	if saved.Len() != 0 {
+		t.Errorf("the save should not be written before the game changes the RAM; got=%v bytes", saved.Len())
+	}
+}
@@ -3,32 +3,126 @@ package main
 import (
 	"flag"
 	"fmt"
+	"image"
+	"image/png"
+	"io"
 	"os"
+	"os/signal"
+	"time"
 
 	"github.com/cd1/nes-emulator"
 )
 
 var verbose bool
+var nestestAutomation bool
+var jamPolicy string
+var cycleAccurate bool
+var saveDir string
+var coins uint
+var playTime time.Duration
+var framePath string
+var frames uint64
 
 func init() {
 	flag.BoolVar(&verbose, "v", false, "Display information when executing each instruction")
+	flag.BoolVar(&nestestAutomation, "nestest", false, "Start the execution at $C000, which runs nestest in automation mode")
+	flag.StringVar(&jamPolicy, "jam", nes.JamPolicyHalt.String(), "What to do when the CPU jams: halt (like the hardware, the same as the library's default; the jam is logged and the emulator keeps running), error (stop with an error) or nop")
+	flag.BoolVar(&cycleAccurate, "accurate", false, "Make every memory access take its own CPU cycle")
+	flag.StringVar(&saveDir, "save-dir", "", "Directory of the battery saves (default: next to the ROM)")
+	flag.StringVar(&framePath, "frame", "", "PNG file where the last frame is written when the emulator stops")
+	flag.Uint64Var(&frames, "frames", 0, "Number of frames drawn before the emulator stops (default: until it's interrupted)")
+	flag.UintVar(&coins, "coins", 0, "Number of coins inserted in a PlayChoice-10 game, which otherwise runs in attract mode")
+	flag.DurationVar(&playTime, "play-time", nes.PlayChoiceDefaultPlayTime, "Play time bought by each coin in a PlayChoice-10 game")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %v [options] [ROM]\n\nThe ROM is read from the standard input when it's not given; its battery save is not kept in that case.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
 }
 
 func main() {
 	flag.Parse()
 
-	game, err := nes.LoadGame(os.Stdin)
+	policy, err := nes.ParseJamPolicy(jamPolicy)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid option: %v.\n", err)
+		os.Exit(1)
+	}
+
+	var rom io.Reader = os.Stdin
+	romPath := flag.Arg(0)
+
+	if romPath != "" {
+		romFile, err := os.Open(romPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open the game: %v.\n", err)
+			os.Exit(1)
+		}
+		defer romFile.Close()
+
+		rom = romFile
+	}
+
+	game, err := nes.LoadGame(rom)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load the game: %v.\n", err)
 		os.Exit(1)
 	}
 
+	for _, warning := range game.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %v.\n", warning)
+	}
+
 	system := nes.NES{
-		Verbose: verbose,
+		Verbose:           verbose,
+		NestestAutomation: nestestAutomation,
+		JamPolicy:         policy,
+		CycleAccurate:     cycleAccurate,
+		Frames:            frames,
+		PlayChoiceTime:    playTime,
+	}
+
+	if romPath != "" {
+		system.Save = nes.NewSaveFile(nes.SavePath(romPath, saveDir))
+		system.SaveInterval = nes.CPUClockRate
 	}
 
-	if err := system.Run(*game); err != nil {
-		fmt.Fprintf(os.Stderr, "Failed to run the game: %v.\n", err)
+	for i := uint(0); i < coins; i++ {
+		system.InsertCoin()
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+
+	go func() {
+		<-interrupt
+		system.Stop()
+	}()
+
+	runErr := system.Run(*game)
+
+	if framePath != "" && system.PPU != nil {
+		if err := writeFrame(framePath, system.Frame()); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write the last frame: %v.\n", err)
+		}
+	}
+
+	if runErr != nil {
+		fmt.Fprintf(os.Stderr, "Failed to run the game: %v.\n", runErr)
 		os.Exit(1)
 	}
 }
+
+func writeFrame(path string, frame image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := png.Encode(f, frame); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	// Mnemonic for ADC operations
 	OpMnemonicADC = "ADC"
@@ -52,7 +44,7 @@ func NewADCIndirectX(indirectAddress uint8) *ADC {
 			code:        OpCodeADCIndirectX,
 			addressMode: AddrModeIndirectX,
 			mnemonic:    OpMnemonicADC,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -64,7 +56,7 @@ func NewADCZero(zeroAddress uint8) *ADC {
 			code:        OpCodeADCZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicADC,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -76,7 +68,7 @@ func NewADCImmediate(value uint8) *ADC {
 			code:        OpCodeADCImmediate,
 			addressMode: AddrModeImmediate,
 			mnemonic:    OpMnemonicADC,
-			args:        []uint8{value},
+			args:        [2]uint8{value},
 		},
 	}
 }
@@ -88,7 +80,7 @@ func NewADCAbsolute(absoluteAddress uint16) *ADC {
 			code:        OpCodeADCAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicADC,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -100,7 +92,7 @@ func NewADCIndirectY(indirectAddress uint8) *ADC {
 			code:        OpCodeADCIndirectY,
 			addressMode: AddrModeIndirectY,
 			mnemonic:    OpMnemonicADC,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -112,7 +104,7 @@ func NewADCZeroX(zeroAddress uint8) *ADC {
 			code:        OpCodeADCZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicADC,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -124,7 +116,7 @@ func NewADCAbsoluteY(absoluteAddress uint16) *ADC {
 			code:        OpCodeADCAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicADC,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -136,159 +128,77 @@ func NewADCAbsoluteX(absoluteAddress uint16) *ADC {
 			code:        OpCodeADCAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicADC,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-// NewADCBinary creates a new ADC operation according to
-// the given opCode. The rest of the parameters, if needed, will be read from
-// data. If opCode isn't a valid ADC code or if there's an error reading from
-// data, an error is returned.
-func NewADCBinary(opCode uint8, data io.Reader) (*ADC, error) {
-	switch opCode {
-	case OpCodeADCIndirectX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewADCIndirectX(addr), nil
-	case OpCodeADCZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewADCZero(addr), nil
-	case OpCodeADCImmediate:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewADCImmediate(addr), nil
-	case OpCodeADCAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
+func executeADC(op Operation, env OperationEnvironment) (uint8, error) {
+	cycles := op.Cycles()
+	_, operand, pageCrossed := env.FetchOperand(op)
 
-		return NewADCAbsolute(addr), nil
-	case OpCodeADCIndirectY:
-		var addr uint8
+	if pageCrossed {
+		cycles++
+	}
 
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
+	cycles += addWithCarry(env, operand)
 
-		return NewADCIndirectY(addr), nil
-	case OpCodeADCZeroX:
-		var addr uint8
+	env.IncrementProgramCounter(op.Size())
 
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
+	return cycles, nil
+}
 
-		return NewADCZeroX(addr), nil
-	case OpCodeADCAbsoluteY:
-		var addr uint16
+// addWithCarry adds value and the carry to the accumulator, setting the flags
+// like ADC does. The number of extra cycles taken is returned.
+func addWithCarry(env OperationEnvironment, value uint8) uint8 {
+	oldA := env.GetAccumulator()
 
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
+	var carry uint16
+	if env.IsStatusCarry() {
+		carry = 1
+	}
 
-		return NewADCAbsoluteY(addr), nil
-	case OpCodeADCAbsoluteX:
-		var addr uint16
+	result := uint16(oldA) + uint16(value) + carry
+	newA := uint8(result)
 
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
+	if !isDecimalModeActive(env) {
+		env.SetAccumulator(newA)
+		env.SetStatusCarry(result&0x0100 != 0x00)
+		env.SetStatusZero(newA == 0x00)
+		env.SetStatusOverflow((oldA^newA)&(value^newA)&0x80 != 0x00)
+		env.SetStatusNegative(newA&0x80 != 0x00)
 
-		return NewADCAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
+		return 0
 	}
-}
 
-func NewADCFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*ADC, error) {
-	switch addrMode {
-	case AddrModeIndirectX:
-		return NewADCIndirectX(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewADCZero(arg0), nil
-	case AddrModeImmediate:
-		return NewADCImmediate(arg0), nil
-	case AddrModeAbsolute:
-		return NewADCAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeIndirectY:
-		return NewADCIndirectY(arg0), nil
-	case AddrModeZeroX:
-		return NewADCZeroX(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewADCAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeAbsoluteX:
-		return NewADCAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
+	// each nibble is a decimal digit, adjusted separately
+	low := int16(oldA&0x0F) + int16(value&0x0F) + int16(carry)
+	if low >= 0x0A {
+		low = ((low + 0x06) & 0x0F) + 0x10
 	}
-}
 
-func (op ADC) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeIndirectX:
-		return 6
-	case AddrModeZero:
-		return 3
-	case AddrModeImmediate:
-		return 2
-	case AddrModeAbsolute:
-		return 4
-	case AddrModeIndirectY:
-		return 5
-	case AddrModeZeroX:
-		return 4
-	case AddrModeAbsoluteY:
-		return 4
-	case AddrModeAbsoluteX:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-func (op ADC) ExecuteIn(env OperationEnvironment) (uint8, error) {
-	cycles := op.Cycles()
-	_, operand, pageCrossed := env.FetchOperand(op)
+	decimalResult := int16(oldA&0xF0) + int16(value&0xF0) + low
+	// N and V are calculated before adjusting the high nibble, with signed values
+	signedResult := int16(int8(oldA&0xF0)) + int16(int8(value&0xF0)) + low
 
-	if pageCrossed {
-		cycles++
+	if decimalResult >= 0xA0 {
+		decimalResult += 0x60
 	}
 
-	oldA := env.GetAccumulator()
-	result := uint16(oldA) + uint16(operand)
-	if env.IsStatusCarry() {
-		result++
+	env.SetAccumulator(uint8(decimalResult))
+	env.SetStatusCarry(decimalResult >= 0x0100)
+	env.SetStatusOverflow(signedResult < -128 || signedResult > 127)
+
+	if env.GetVariant() == Variant65C02 {
+		env.SetStatusZero(uint8(decimalResult) == 0x00)
+		env.SetStatusNegative(decimalResult&0x80 != 0x00)
+
+		return 1
 	}
 
-	newA := uint8(result)
-	env.SetAccumulator(newA)
-	env.SetStatusCarry(result&0x0100 != 0x00)
+	// the NMOS 6502 sets Z from the binary result
 	env.SetStatusZero(newA == 0x00)
-	env.SetStatusOverflow((oldA&0x80 == 0x00 && operand&0x80 == 0x00 && result&0x80 != 0x00) ||
-		(oldA&0x80 != 0x00 && operand&0x80 != 0x00 && result&0x80 == 0x00))
-	env.SetStatusNegative(newA&0x80 != 0x00)
+	env.SetStatusNegative(signedResult&0x80 != 0x00)
 
-	env.IncrementProgramCounter(op.Size())
-
-	return cycles, nil
+	return 0
 }
@@ -0,0 +1,50 @@
+package cpu
+
+const (
+	OpMnemonicALR = "ALR"
+
+	// unofficial opcodes
+	OpCodeUnALRImmediate = 0x4B
+)
+
+func IsOpCodeValidALR(opCode uint8) bool {
+	return opCode == OpCodeUnALRImmediate
+}
+
+func IsMnemonicValidALR(mnemonic string) bool {
+	return mnemonic == OpMnemonicALR
+}
+
+type ALR struct {
+	baseOperation
+}
+
+// 0x4B: ALR #$NN
+func NewUnALRImmediate(value uint8) *ALR {
+	return &ALR{
+		baseOperation{
+			code:        OpCodeUnALRImmediate,
+			addressMode: AddrModeImmediate,
+			mnemonic:    OpMnemonicALR,
+			args:        [2]uint8{value},
+			unofficial:  true,
+		},
+	}
+}
+
+func executeALR(op Operation, env OperationEnvironment) (uint8, error) {
+	cycles := op.Cycles()
+	_, operand, _ := env.FetchOperand(op)
+
+	value := env.GetAccumulator() & operand
+	result := value >> 1
+	env.SetAccumulator(result)
+
+	env.SetStatusCarry(value&0x01 != 0x00)
+	env.SetStatusZero(result == 0x00)
+	env.SetStatusNegative(result&0x80 != 0x00)
+
+	env.IncrementProgramCounter(op.Size())
+
+	return cycles, nil
+}
@@ -0,0 +1,65 @@
+package cpu
+
+const (
+	OpMnemonicANC = "ANC"
+
+	// unofficial opcodes
+	OpCodeUnANCImmediate0 = 0x0B
+	OpCodeUnANCImmediate1 = 0x2B
+)
+
+func IsOpCodeValidANC(opCode uint8) bool {
+	return opCode == OpCodeUnANCImmediate0 ||
+		opCode == OpCodeUnANCImmediate1
+}
+
+func IsMnemonicValidANC(mnemonic string) bool {
+	return mnemonic == OpMnemonicANC
+}
+
+type ANC struct {
+	baseOperation
+}
+
+// 0x0B: ANC #$NN
+func NewUnANCImmediate0(value uint8) *ANC {
+	return &ANC{
+		baseOperation{
+			code:        OpCodeUnANCImmediate0,
+			addressMode: AddrModeImmediate,
+			mnemonic:    OpMnemonicANC,
+			args:        [2]uint8{value},
+			unofficial:  true,
+		},
+	}
+}
+
+// 0x2B: ANC #$NN
+func NewUnANCImmediate1(value uint8) *ANC {
+	return &ANC{
+		baseOperation{
+			code:        OpCodeUnANCImmediate1,
+			addressMode: AddrModeImmediate,
+			mnemonic:    OpMnemonicANC,
+			args:        [2]uint8{value},
+			unofficial:  true,
+		},
+	}
+}
+
+func executeANC(op Operation, env OperationEnvironment) (uint8, error) {
+	cycles := op.Cycles()
+	_, operand, _ := env.FetchOperand(op)
+
+	result := env.GetAccumulator() & operand
+	env.SetAccumulator(result)
+
+	// bit 7 is copied to the carry, as if the result was shifted by ASL/ROL
+	env.SetStatusCarry(result&0x80 != 0x00)
+	env.SetStatusZero(result == 0x00)
+	env.SetStatusNegative(result&0x80 != 0x00)
+
+	env.IncrementProgramCounter(op.Size())
+
+	return cycles, nil
+}
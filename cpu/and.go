@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicAND = "AND"
 
@@ -46,7 +38,7 @@ func NewANDIndirectX(indirectAddress uint8) *AND {
 			code:        OpCodeANDIndirectX,
 			addressMode: AddrModeIndirectX,
 			mnemonic:    OpMnemonicAND,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -57,7 +49,7 @@ func NewANDZero(zeroAddress uint8) *AND {
 			code:        OpCodeANDZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicAND,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -68,7 +60,7 @@ func NewANDImmediate(value uint8) *AND {
 			code:        OpCodeANDImmediate,
 			addressMode: AddrModeImmediate,
 			mnemonic:    OpMnemonicAND,
-			args:        []uint8{value},
+			args:        [2]uint8{value},
 		},
 	}
 }
@@ -79,7 +71,7 @@ func NewANDAbsolute(absoluteAddress uint16) *AND {
 			code:        OpCodeANDAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicAND,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -90,7 +82,7 @@ func NewANDIndirectY(indirectAddress uint8) *AND {
 			code:        OpCodeANDIndirectY,
 			addressMode: AddrModeIndirectY,
 			mnemonic:    OpMnemonicAND,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -101,7 +93,7 @@ func NewANDZeroX(zeroAddress uint8) *AND {
 			code:        OpCodeANDZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicAND,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -112,7 +104,7 @@ func NewANDAbsoluteY(absoluteAddress uint16) *AND {
 			code:        OpCodeANDAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicAND,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -123,132 +115,12 @@ func NewANDAbsoluteX(absoluteAddress uint16) *AND {
 			code:        OpCodeANDAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicAND,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewANDBinary(opCode uint8, data io.Reader) (*AND, error) {
-	switch opCode {
-	case OpCodeANDIndirectX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewANDIndirectX(addr), nil
-	case OpCodeANDZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewANDZero(addr), nil
-	case OpCodeANDImmediate:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewANDImmediate(addr), nil
-	case OpCodeANDAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewANDAbsolute(addr), nil
-	case OpCodeANDIndirectY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewANDIndirectY(addr), nil
-	case OpCodeANDZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewANDZeroX(addr), nil
-	case OpCodeANDAbsoluteY:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewANDAbsoluteY(addr), nil
-	case OpCodeANDAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewANDAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{opCode}
-	}
-}
-
-func NewANDFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*AND, error) {
-	switch addrMode {
-	case AddrModeIndirectX:
-		return NewANDIndirectX(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewANDZero(arg0), nil
-	case AddrModeImmediate:
-		return NewANDImmediate(arg0), nil
-	case AddrModeAbsolute:
-		return NewANDAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeIndirectY:
-		return NewANDIndirectY(arg0), nil
-	case AddrModeZeroX:
-		return NewANDZeroX(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewANDAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeAbsoluteX:
-		return NewANDAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op AND) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeIndirectX:
-		return 6
-	case AddrModeZero:
-		return 3
-	case AddrModeImmediate:
-		return 2
-	case AddrModeAbsolute:
-		return 4
-	case AddrModeIndirectY:
-		return 5
-	case AddrModeZeroX:
-		return 4
-	case AddrModeAbsoluteY:
-		return 4
-	case AddrModeAbsoluteX:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op AND) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeAND(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	_, operand, pageCrossed := env.FetchOperand(op)
 
@@ -0,0 +1,77 @@
+package cpu
+
+const (
+	OpMnemonicARR = "ARR"
+
+	// unofficial opcodes
+	OpCodeUnARRImmediate = 0x6B
+)
+
+func IsOpCodeValidARR(opCode uint8) bool {
+	return opCode == OpCodeUnARRImmediate
+}
+
+func IsMnemonicValidARR(mnemonic string) bool {
+	return mnemonic == OpMnemonicARR
+}
+
+type ARR struct {
+	baseOperation
+}
+
+// 0x6B: ARR #$NN
+func NewUnARRImmediate(value uint8) *ARR {
+	return &ARR{
+		baseOperation{
+			code:        OpCodeUnARRImmediate,
+			addressMode: AddrModeImmediate,
+			mnemonic:    OpMnemonicARR,
+			args:        [2]uint8{value},
+			unofficial:  true,
+		},
+	}
+}
+
+func executeARR(op Operation, env OperationEnvironment) (uint8, error) {
+	cycles := op.Cycles()
+	_, operand, _ := env.FetchOperand(op)
+
+	value := env.GetAccumulator() & operand
+	oldCarry := env.IsStatusCarry()
+
+	result := value >> 1
+	if oldCarry {
+		result |= 0x80
+	}
+
+	env.SetStatusZero(result == 0x00)
+	env.SetStatusNegative(result&0x80 != 0x00)
+
+	if isDecimalModeActive(env) {
+		// the overflow comes from the value before the rotation, and each
+		// nibble is adjusted like a decimal digit
+		env.SetStatusOverflow((value^result)&0x40 != 0x00)
+
+		if uint16(value&0x0F)+uint16(value&0x01) > 0x05 {
+			result = result&0xF0 | (result+0x06)&0x0F
+		}
+
+		highAdjust := uint16(value&0xF0)+uint16(value&0x10) > 0x50
+		if highAdjust {
+			result += 0x60
+		}
+
+		env.SetAccumulator(result)
+		env.SetStatusCarry(highAdjust)
+	} else {
+		env.SetAccumulator(result)
+
+		// the carry and the overflow come from the adder, not from the rotation
+		env.SetStatusCarry(result&0x40 != 0x00)
+		env.SetStatusOverflow((result>>6)&0x01 != (result>>5)&0x01)
+	}
+
+	env.IncrementProgramCounter(op.Size())
+
+	return cycles, nil
+}
@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicASL = "ASL"
 
@@ -40,7 +32,7 @@ func NewASLZero(zeroAddress uint8) *ASL {
 			code:        OpCodeASLZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicASL,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -61,7 +53,7 @@ func NewASLAbsolute(absoluteAddress uint16) *ASL {
 			code:        OpCodeASLAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicASL,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -72,7 +64,7 @@ func NewASLZeroX(zeroAddress uint8) *ASL {
 			code:        OpCodeASLZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicASL,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -83,92 +75,12 @@ func NewASLAbsoluteX(absoluteAddress uint16) *ASL {
 			code:        OpCodeASLAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicASL,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewASLBinary(opCode uint8, data io.Reader) (*ASL, error) {
-	switch opCode {
-	case OpCodeASLZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewASLZero(addr), nil
-	case OpCodeASLAccumulator:
-		return NewASLAccumulator(), nil
-	case OpCodeASLAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewASLAbsolute(addr), nil
-	case OpCodeASLZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewASLZeroX(addr), nil
-	case OpCodeASLAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewASLAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewASLFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*ASL, error) {
-	switch addrMode {
-	case AddrModeZero, AddrModeRelative:
-		return NewASLZero(arg0), nil
-	case AddrModeAccumulator:
-		return NewASLAccumulator(), nil
-	case AddrModeAbsolute:
-		return NewASLAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeZeroX:
-		return NewASLZeroX(arg0), nil
-	case AddrModeAbsoluteX:
-		return NewASLAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op ASL) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeZero:
-		return 5
-	case AddrModeAccumulator:
-		return 2
-	case AddrModeAbsolute:
-		return 6
-	case AddrModeZeroX:
-		return 6
-	case AddrModeAbsoluteX:
-		return 7
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op ASL) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeASL(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	address, operand, _ := env.FetchOperand(op)
 
@@ -0,0 +1,51 @@
+package cpu
+
+const (
+	OpMnemonicAXS = "AXS"
+
+	// unofficial opcodes
+	OpCodeUnAXSImmediate = 0xCB
+)
+
+func IsOpCodeValidAXS(opCode uint8) bool {
+	return opCode == OpCodeUnAXSImmediate
+}
+
+func IsMnemonicValidAXS(mnemonic string) bool {
+	return mnemonic == OpMnemonicAXS
+}
+
+type AXS struct {
+	baseOperation
+}
+
+// 0xCB: AXS #$NN
+func NewUnAXSImmediate(value uint8) *AXS {
+	return &AXS{
+		baseOperation{
+			code:        OpCodeUnAXSImmediate,
+			addressMode: AddrModeImmediate,
+			mnemonic:    OpMnemonicAXS,
+			args:        [2]uint8{value},
+			unofficial:  true,
+		},
+	}
+}
+
+func executeAXS(op Operation, env OperationEnvironment) (uint8, error) {
+	cycles := op.Cycles()
+	_, operand, _ := env.FetchOperand(op)
+
+	// like CMP, the carry is not used as a borrow and the overflow is not affected
+	value := env.GetAccumulator() & env.GetIndexX()
+	result := value - operand
+	env.SetIndexX(result)
+
+	env.SetStatusCarry(value >= operand)
+	env.SetStatusZero(result == 0x00)
+	env.SetStatusNegative(result&0x80 != 0x00)
+
+	env.IncrementProgramCounter(op.Size())
+
+	return cycles, nil
+}
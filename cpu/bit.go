@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicBIT = "BIT"
 
@@ -34,7 +26,7 @@ func NewBITZero(zeroAddress uint8) *BIT {
 			code:        OpCodeBITZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicBIT,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -45,62 +37,12 @@ func NewBITAbsolute(absoluteAddress uint16) *BIT {
 			code:        OpCodeBITAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicBIT,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewBITBinary(opCode uint8, data io.Reader) (*BIT, error) {
-	switch opCode {
-	case OpCodeBITZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewBITZero(addr), nil
-	case OpCodeBITAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewBITAbsolute(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewBITFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*BIT, error) {
-	switch addrMode {
-	case AddrModeZero, AddrModeRelative:
-		return NewBITZero(arg0), nil
-	case AddrModeAbsolute:
-		return NewBITAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op BIT) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeZero:
-		return 3
-	case AddrModeAbsolute:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op BIT) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeBIT(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	_, operand, _ := env.FetchOperand(op)
 
@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicBRK = "BRK"
 
@@ -36,47 +31,14 @@ func NewBRK() *BRK {
 	}
 }
 
-func NewBRKBinary(opCode uint8, data io.Reader) (*BRK, error) {
-	switch opCode {
-	case OpCodeBRK:
-		return NewBRK(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewBRKFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*BRK, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewBRK(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op BRK) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 7
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op BRK) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeBRK(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
-	env.SetStatusBreak(true)
-	// TODO: put this login in GetStatus? but what about /IRQ and /NMI?
-	// TODO: unused?
-	env.PushWordToStack(env.GetProgramCounter() + uint16(op.Size()))
-	env.PushByteToStack(env.GetStatus())
+	// BRK is followed by a padding byte, which is skipped when returning
+	env.PushWordToStack(env.GetProgramCounter() + uint16(op.Size()) + 1)
+	pushStatus(env, true)
 
+	env.SetStatusInterrupt(true)
 	env.SetProgramCounter(env.ReadWord(InterruptVectorAddress))
 
 	return cycles, nil
@@ -1,11 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicBVC = "BVC"
 
@@ -31,50 +25,12 @@ func NewBVC(relativeAddress uint8) *BVC {
 			code:        OpCodeBVC,
 			addressMode: AddrModeRelative,
 			mnemonic:    OpMnemonicBVC,
-			args:        []uint8{relativeAddress},
+			args:        [2]uint8{relativeAddress},
 		},
 	}
 }
 
-func NewBVCBinary(opCode uint8, data io.Reader) (*BVC, error) {
-	switch opCode {
-	case OpCodeBVC:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewBVC(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewBVCFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*BVC, error) {
-	switch addrMode {
-	case AddrModeZero, AddrModeRelative:
-		return NewBVC(arg0), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op BVC) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeRelative:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op BVC) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeBVC(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	_, operand, pageCrossed := env.FetchOperand(op)
 
@@ -1,11 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicBVS = "BVS"
 
@@ -31,50 +25,12 @@ func NewBVS(relativeAddress uint8) *BVS {
 			code:        OpCodeBVS,
 			addressMode: AddrModeRelative,
 			mnemonic:    OpMnemonicBVS,
-			args:        []uint8{relativeAddress},
+			args:        [2]uint8{relativeAddress},
 		},
 	}
 }
 
-func NewBVSBinary(opCode uint8, data io.Reader) (*BVS, error) {
-	switch opCode {
-	case OpCodeBVS:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewBVS(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewBVSFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*BVS, error) {
-	switch addrMode {
-	case AddrModeZero, AddrModeRelative:
-		return NewBVS(arg0), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op BVS) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeRelative:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op BVS) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeBVS(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	_, operand, pageCrossed := env.FetchOperand(op)
 
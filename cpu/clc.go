@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicCLC = "CLC"
 
@@ -34,39 +29,7 @@ func NewCLC() *CLC {
 	}
 }
 
-func NewCLCBinary(opCode uint8, data io.Reader) (*CLC, error) {
-	switch opCode {
-	case OpCodeCLC:
-		return NewCLC(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewCLCFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*CLC, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewCLC(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op CLC) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op CLC) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeCLC(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	env.SetStatusCarry(false)
@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicCLI = "CLI"
 
@@ -34,39 +29,7 @@ func NewCLI() *CLI {
 	}
 }
 
-func NewCLIBinary(opCode uint8, data io.Reader) (*CLI, error) {
-	switch opCode {
-	case OpCodeCLI:
-		return NewCLI(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewCLIFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*CLI, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewCLI(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op CLI) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op CLI) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeCLI(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	env.SetStatusInterrupt(false)
@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicCLV = "CLV"
 
@@ -34,39 +29,7 @@ func NewCLV() *CLV {
 	}
 }
 
-func NewCLVBinary(opCode uint8, data io.Reader) (*CLV, error) {
-	switch opCode {
-	case OpCodeCLV:
-		return NewCLV(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewCLVFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*CLV, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewCLV(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op CLV) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op CLV) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeCLV(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	env.SetStatusOverflow(false)
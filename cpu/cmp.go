@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicCMP = "CMP"
 
@@ -47,7 +39,7 @@ func NewCMPIndirectX(indirectAddress uint8) *CMP {
 			code:        OpCodeCMPIndirectX,
 			addressMode: AddrModeIndirectX,
 			mnemonic:    OpMnemonicCMP,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -59,7 +51,7 @@ func NewCMPZero(zeroAddress uint8) *CMP {
 			code:        OpCodeCMPZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicCMP,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -71,7 +63,7 @@ func NewCMPImmediate(value uint8) *CMP {
 			code:        OpCodeCMPImmediate,
 			addressMode: AddrModeImmediate,
 			mnemonic:    OpMnemonicCMP,
-			args:        []uint8{value},
+			args:        [2]uint8{value},
 		},
 	}
 }
@@ -83,7 +75,7 @@ func NewCMPAbsolute(absoluteAddress uint16) *CMP {
 			code:        OpCodeCMPAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicCMP,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -95,7 +87,7 @@ func NewCMPIndirectY(indirectAddress uint8) *CMP {
 			code:        OpCodeCMPIndirectY,
 			addressMode: AddrModeIndirectY,
 			mnemonic:    OpMnemonicCMP,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -107,7 +99,7 @@ func NewCMPZeroX(zeroAddress uint8) *CMP {
 			code:        OpCodeCMPZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicCMP,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -119,7 +111,7 @@ func NewCMPAbsoluteY(absoluteAddress uint16) *CMP {
 			code:        OpCodeCMPAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicCMP,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -131,134 +123,12 @@ func NewCMPAbsoluteX(absoluteAddress uint16) *CMP {
 			code:        OpCodeCMPAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicCMP,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewCMPBinary(opCode uint8, data io.Reader) (*CMP, error) {
-	switch opCode {
-	case OpCodeCMPIndirectX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewCMPIndirectX(addr), nil
-	case OpCodeCMPZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewCMPZero(addr), nil
-	case OpCodeCMPImmediate:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewCMPImmediate(addr), nil
-	case OpCodeCMPAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewCMPAbsolute(addr), nil
-	case OpCodeCMPIndirectY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewCMPIndirectY(addr), nil
-	case OpCodeCMPZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewCMPZeroX(addr), nil
-	case OpCodeCMPAbsoluteY:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewCMPAbsoluteY(addr), nil
-	case OpCodeCMPAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewCMPAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewCMPFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*CMP, error) {
-	switch addrMode {
-	case AddrModeIndirectX:
-		return NewCMPIndirectX(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewCMPZero(arg0), nil
-	case AddrModeImmediate:
-		return NewCMPImmediate(arg0), nil
-	case AddrModeAbsolute:
-		return NewCMPAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeIndirectY:
-		return NewCMPIndirectY(arg0), nil
-	case AddrModeZeroX:
-		return NewCMPZeroX(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewCMPAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeAbsoluteX:
-		return NewCMPAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op CMP) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeIndirectX:
-		return 6
-	case AddrModeZero:
-		return 3
-	case AddrModeImmediate:
-		return 2
-	case AddrModeAbsolute:
-		return 4
-	case AddrModeIndirectY:
-		return 5
-	case AddrModeZeroX:
-		return 4
-	case AddrModeAbsoluteY:
-		return 4
-	case AddrModeAbsoluteX:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op CMP) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeCMP(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	_, operand, pageCrossed := env.FetchOperand(op)
 
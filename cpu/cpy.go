@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicCPY = "CPY"
 
@@ -37,7 +29,7 @@ func NewCPYImmediate(value uint8) *CPY {
 			code:        OpCodeCPYImmediate,
 			addressMode: AddrModeImmediate,
 			mnemonic:    OpMnemonicCPY,
-			args:        []uint8{value},
+			args:        [2]uint8{value},
 		},
 	}
 }
@@ -49,7 +41,7 @@ func NewCPYZero(zeroAddress uint8) *CPY {
 			code:        OpCodeCPYZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicCPY,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -61,74 +53,12 @@ func NewCPYAbsolute(absoluteAddress uint16) *CPY {
 			code:        OpCodeCPYAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicCPY,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewCPYBinary(opCode uint8, data io.Reader) (*CPY, error) {
-	switch opCode {
-	case OpCodeCPYImmediate:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewCPYImmediate(addr), nil
-	case OpCodeCPYZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewCPYZero(addr), nil
-	case OpCodeCPYAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewCPYAbsolute(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewCPYFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*CPY, error) {
-	switch addrMode {
-	case AddrModeImmediate:
-		return NewCPYImmediate(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewCPYZero(arg0), nil
-	case AddrModeAbsolute:
-		return NewCPYAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op CPY) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeZero:
-		return 3
-	case AddrModeImmediate:
-		return 2
-	case AddrModeAbsolute:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op CPY) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeCPY(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	_, operand, _ := env.FetchOperand(op)
 
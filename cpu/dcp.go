@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicDCP = "DCP"
 
@@ -46,7 +38,7 @@ func NewUnDCPIndirectX(indirectAddress uint8) *DCP {
 			code:        OpCodeUnDCPIndirectX,
 			addressMode: AddrModeIndirectX,
 			mnemonic:    OpMnemonicDCP,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 			unofficial:  true,
 		},
 	}
@@ -59,7 +51,7 @@ func NewUnDCPZero(zeroAddress uint8) *DCP {
 			code:        OpCodeUnDCPZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicDCP,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -72,7 +64,7 @@ func NewUnDCPAbsolute(absoluteAddress uint16) *DCP {
 			code:        OpCodeUnDCPAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicDCP,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -85,7 +77,7 @@ func NewUnDCPIndirectY(indirectAddress uint8) *DCP {
 			code:        OpCodeUnDCPIndirectY,
 			addressMode: AddrModeIndirectY,
 			mnemonic:    OpMnemonicDCP,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 			unofficial:  true,
 		},
 	}
@@ -98,7 +90,7 @@ func NewUnDCPZeroX(zeroAddress uint8) *DCP {
 			code:        OpCodeUnDCPZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicDCP,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -111,7 +103,7 @@ func NewUnDCPAbsoluteY(absoluteAddress uint16) *DCP {
 			code:        OpCodeUnDCPAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicDCP,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -124,123 +116,13 @@ func NewUnDCPAbsoluteX(absoluteAddress uint16) *DCP {
 			code:        OpCodeUnDCPAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicDCP,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
 }
 
-func NewDCPBinary(opCode uint8, data io.Reader) (*DCP, error) {
-	switch opCode {
-	case OpCodeUnDCPIndirectX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnDCPIndirectX(addr), nil
-	case OpCodeUnDCPZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnDCPZero(addr), nil
-	case OpCodeUnDCPAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnDCPAbsolute(addr), nil
-	case OpCodeUnDCPIndirectY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnDCPIndirectY(addr), nil
-	case OpCodeUnDCPZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnDCPZeroX(addr), nil
-	case OpCodeUnDCPAbsoluteY:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnDCPAbsoluteY(addr), nil
-	case OpCodeUnDCPAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnDCPAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewDCPFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*DCP, error) {
-	switch addrMode {
-	case AddrModeIndirectX:
-		return NewUnDCPIndirectX(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewUnDCPZero(arg0), nil
-	case AddrModeAbsolute:
-		return NewUnDCPAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeIndirectY:
-		return NewUnDCPIndirectY(arg0), nil
-	case AddrModeZeroX:
-		return NewUnDCPZeroX(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewUnDCPAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeAbsoluteX:
-		return NewUnDCPAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op DCP) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeIndirectX:
-		return 8
-	case AddrModeZero:
-		return 5
-	case AddrModeAbsolute:
-		return 6
-	case AddrModeIndirectY:
-		return 8
-	case AddrModeZeroX:
-		return 6
-	case AddrModeAbsoluteY:
-		return 7
-	case AddrModeAbsoluteX:
-		return 7
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op DCP) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeDCP(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	address, operand, _ := env.FetchOperand(op)
 
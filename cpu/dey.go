@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicDEY = "DEY"
 
@@ -34,39 +29,7 @@ func NewDEY() *DEY {
 	}
 }
 
-func NewDEYBinary(opCode uint8, data io.Reader) (*DEY, error) {
-	switch opCode {
-	case OpCodeDEY:
-		return NewDEY(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewDEYFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*DEY, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewDEY(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op DEY) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op DEY) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeDEY(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	newY := env.GetIndexY() - 1
@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicEOR = "EOR"
 
@@ -47,7 +39,7 @@ func NewEORIndirectX(indirectAddress uint8) *EOR {
 			code:        OpCodeEORIndirectX,
 			addressMode: AddrModeIndirectX,
 			mnemonic:    OpMnemonicEOR,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -59,7 +51,7 @@ func NewEORZero(zeroAddress uint8) *EOR {
 			code:        OpCodeEORZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicEOR,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -71,7 +63,7 @@ func NewEORImmediate(value uint8) *EOR {
 			code:        OpCodeEORImmediate,
 			addressMode: AddrModeImmediate,
 			mnemonic:    OpMnemonicEOR,
-			args:        []uint8{value},
+			args:        [2]uint8{value},
 		},
 	}
 }
@@ -83,7 +75,7 @@ func NewEORAbsolute(absoluteAddress uint16) *EOR {
 			code:        OpCodeEORAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicEOR,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -95,7 +87,7 @@ func NewEORIndirectY(indirectAddress uint8) *EOR {
 			code:        OpCodeEORIndirectY,
 			addressMode: AddrModeIndirectY,
 			mnemonic:    OpMnemonicEOR,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -107,7 +99,7 @@ func NewEORZeroX(zeroAddress uint8) *EOR {
 			code:        OpCodeEORZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicEOR,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -119,7 +111,7 @@ func NewEORAbsoluteY(absoluteAddress uint16) *EOR {
 			code:        OpCodeEORAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicEOR,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -131,134 +123,12 @@ func NewEORAbsoluteX(absoluteAddress uint16) *EOR {
 			code:        OpCodeEORAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicEOR,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewEORBinary(opCode uint8, data io.Reader) (*EOR, error) {
-	switch opCode {
-	case OpCodeEORIndirectX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewEORIndirectX(addr), nil
-	case OpCodeEORZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewEORZero(addr), nil
-	case OpCodeEORImmediate:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewEORImmediate(addr), nil
-	case OpCodeEORAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewEORAbsolute(addr), nil
-	case OpCodeEORIndirectY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewEORIndirectY(addr), nil
-	case OpCodeEORZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewEORZeroX(addr), nil
-	case OpCodeEORAbsoluteY:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewEORAbsoluteY(addr), nil
-	case OpCodeEORAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewEORAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewEORFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*EOR, error) {
-	switch addrMode {
-	case AddrModeIndirectX:
-		return NewEORIndirectX(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewEORZero(arg0), nil
-	case AddrModeImmediate:
-		return NewEORImmediate(arg0), nil
-	case AddrModeAbsolute:
-		return NewEORAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeIndirectY:
-		return NewEORIndirectY(arg0), nil
-	case AddrModeZeroX:
-		return NewEORZeroX(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewEORAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeAbsoluteX:
-		return NewEORAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op EOR) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeIndirectX:
-		return 6
-	case AddrModeZero:
-		return 3
-	case AddrModeImmediate:
-		return 2
-	case AddrModeAbsolute:
-		return 4
-	case AddrModeIndirectY:
-		return 5
-	case AddrModeZeroX:
-		return 4
-	case AddrModeAbsoluteY:
-		return 4
-	case AddrModeAbsoluteX:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op EOR) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeEOR(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	_, operand, pageCrossed := env.FetchOperand(op)
 
@@ -10,6 +10,15 @@ func (err InvalidAddressModeError) Error() string {
 	return fmt.Sprintf("invalid address mode: %v", AddressModeString(err.AddressMode))
 }
 
+type CPUJammedError struct {
+	OpCode         uint8
+	ProgramCounter uint16
+}
+
+func (err CPUJammedError) Error() string {
+	return fmt.Sprintf("CPU jammed by op code $%02X at $%04X", err.OpCode, err.ProgramCounter)
+}
+
 type InvalidMnemonicError struct {
 	Mnemonic string
 }
@@ -0,0 +1,37 @@
+package cpu
+
+const (
+	NMIVectorAddress = 0xFFFA
+	IRQVectorAddress = InterruptVectorAddress
+
+	// number of cycles taken by the CPU to handle a hardware interrupt
+	InterruptCycles = 7
+)
+
+// Interrupt makes the CPU handle a hardware interrupt (/NMI or /IRQ): the
+// current program counter and status are pushed to the stack, the interrupt
+// flag is set and the execution continues from the address stored in
+// vectorAddress. Unlike BRK, the status is pushed with the break flag clear.
+// The number of cycles taken is returned.
+func Interrupt(env OperationEnvironment, vectorAddress uint16) uint8 {
+	env.PushWordToStack(env.GetProgramCounter())
+	pushStatus(env, false)
+
+	env.SetStatusInterrupt(true)
+	env.SetProgramCounter(env.ReadWord(vectorAddress))
+
+	return InterruptCycles
+}
+
+// pushStatus pushes the status register to the stack. The break flag doesn't
+// exist in the CPU, it only appears in the value pushed: it's set when the
+// push comes from an instruction (BRK, PHP) and clear when it comes from an
+// interrupt (/IRQ, /NMI).
+func pushStatus(env OperationEnvironment, hasBreak bool) {
+	wasBreak := env.IsStatusBreak()
+
+	env.SetStatusBreak(hasBreak)
+	env.SetStatusUnused(true)
+	env.PushByteToStack(env.GetStatus())
+	env.SetStatusBreak(wasBreak)
+}
@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicINX = "INX"
 
@@ -34,39 +29,7 @@ func NewINX() *INX {
 	}
 }
 
-func NewINXBinary(opCode uint8, data io.Reader) (*INX, error) {
-	switch opCode {
-	case OpCodeINX:
-		return NewINX(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewINXFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*INX, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewINX(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op INX) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op INX) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeINX(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	newX := env.GetIndexX() + 1
@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicINY = "INY"
 
@@ -34,39 +29,7 @@ func NewINY() *INY {
 	}
 }
 
-func NewINYBinary(opCode uint8, data io.Reader) (*INY, error) {
-	switch opCode {
-	case OpCodeINY:
-		return NewINY(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewINYFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*INY, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewINY(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op INY) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op INY) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeINY(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	newY := env.GetIndexY() + 1
@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicISB = "ISB"
 
@@ -46,7 +38,7 @@ func NewUnISBIndirectX(indirectAddress uint8) *ISB {
 			code:        OpCodeUnISBIndirectX,
 			addressMode: AddrModeIndirectX,
 			mnemonic:    OpMnemonicISB,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 			unofficial:  true,
 		},
 	}
@@ -59,7 +51,7 @@ func NewUnISBZero(zeroAddress uint8) *ISB {
 			code:        OpCodeUnISBZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicISB,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -72,7 +64,7 @@ func NewUnISBAbsolute(absoluteAddress uint16) *ISB {
 			code:        OpCodeUnISBAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicISB,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -85,7 +77,7 @@ func NewUnISBIndirectY(indirectAddress uint8) *ISB {
 			code:        OpCodeUnISBIndirectY,
 			addressMode: AddrModeIndirectY,
 			mnemonic:    OpMnemonicISB,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 			unofficial:  true,
 		},
 	}
@@ -98,7 +90,7 @@ func NewUnISBZeroX(zeroAddress uint8) *ISB {
 			code:        OpCodeUnISBZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicISB,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -111,7 +103,7 @@ func NewUnISBAbsoluteY(absoluteAddress uint16) *ISB {
 			code:        OpCodeUnISBAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicISB,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -124,142 +116,20 @@ func NewUnISBAbsoluteX(absoluteAddress uint16) *ISB {
 			code:        OpCodeUnISBAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicISB,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
 }
 
-func NewISBBinary(opCode uint8, data io.Reader) (*ISB, error) {
-	switch opCode {
-	case OpCodeUnISBIndirectX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnISBIndirectX(addr), nil
-	case OpCodeUnISBZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnISBZero(addr), nil
-	case OpCodeUnISBAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnISBAbsolute(addr), nil
-	case OpCodeUnISBIndirectY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnISBIndirectY(addr), nil
-	case OpCodeUnISBZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnISBZeroX(addr), nil
-	case OpCodeUnISBAbsoluteY:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnISBAbsoluteY(addr), nil
-	case OpCodeUnISBAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnISBAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewISBFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*ISB, error) {
-	switch addrMode {
-	case AddrModeIndirectX:
-		return NewUnISBIndirectX(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewUnISBZero(arg0), nil
-	case AddrModeAbsolute:
-		return NewUnISBAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeIndirectY:
-		return NewUnISBIndirectY(arg0), nil
-	case AddrModeZeroX:
-		return NewUnISBZeroX(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewUnISBAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeAbsoluteX:
-		return NewUnISBAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op ISB) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeIndirectX:
-		return 8
-	case AddrModeZero:
-		return 5
-	case AddrModeAbsolute:
-		return 6
-	case AddrModeIndirectY:
-		return 8
-	case AddrModeZeroX:
-		return 6
-	case AddrModeAbsoluteY:
-		return 7
-	case AddrModeAbsoluteX:
-		return 7
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op ISB) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeISB(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	address, operand, _ := env.FetchOperand(op)
 
 	newMemValue := operand + 1
 	env.WriteByte(address, newMemValue)
 
-	oldA := env.GetAccumulator()
-	result := oldA - newMemValue
-	signedResult := int16(int8(oldA)) - int16(int8(newMemValue))
-	if !env.IsStatusCarry() {
-		result--
-		signedResult--
-	}
-
-	env.SetAccumulator(result)
-	env.SetStatusCarry(result <= oldA)
-	env.SetStatusZero(result == 0x00)
-	env.SetStatusOverflow(signedResult > 127 || signedResult < -128)
-	env.SetStatusNegative(result&0x80 != 0x00)
+	cycles += subtractWithBorrow(env, newMemValue)
 
 	env.IncrementProgramCounter(op.Size())
 
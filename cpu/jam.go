@@ -0,0 +1,198 @@
+package cpu
+
+const (
+	OpMnemonicJAM = "JAM"
+
+	// unofficial opcodes
+	OpCodeUnJAM0  = 0x02
+	OpCodeUnJAM1  = 0x12
+	OpCodeUnJAM2  = 0x22
+	OpCodeUnJAM3  = 0x32
+	OpCodeUnJAM4  = 0x42
+	OpCodeUnJAM5  = 0x52
+	OpCodeUnJAM6  = 0x62
+	OpCodeUnJAM7  = 0x72
+	OpCodeUnJAM8  = 0x92
+	OpCodeUnJAM9  = 0xB2
+	OpCodeUnJAM10 = 0xD2
+	OpCodeUnJAM11 = 0xF2
+)
+
+func IsOpCodeValidJAM(opCode uint8) bool {
+	return opCode == OpCodeUnJAM0 ||
+		opCode == OpCodeUnJAM1 ||
+		opCode == OpCodeUnJAM2 ||
+		opCode == OpCodeUnJAM3 ||
+		opCode == OpCodeUnJAM4 ||
+		opCode == OpCodeUnJAM5 ||
+		opCode == OpCodeUnJAM6 ||
+		opCode == OpCodeUnJAM7 ||
+		opCode == OpCodeUnJAM8 ||
+		opCode == OpCodeUnJAM9 ||
+		opCode == OpCodeUnJAM10 ||
+		opCode == OpCodeUnJAM11
+}
+
+func IsMnemonicValidJAM(mnemonic string) bool {
+	return mnemonic == OpMnemonicJAM
+}
+
+type JAM struct {
+	baseOperation
+}
+
+// 0x02: JAM
+func NewUnJAM0() *JAM {
+	return &JAM{
+		baseOperation{
+			code:        OpCodeUnJAM0,
+			addressMode: AddrModeImplied,
+			mnemonic:    OpMnemonicJAM,
+			unofficial:  true,
+		},
+	}
+}
+
+// 0x12: JAM
+func NewUnJAM1() *JAM {
+	return &JAM{
+		baseOperation{
+			code:        OpCodeUnJAM1,
+			addressMode: AddrModeImplied,
+			mnemonic:    OpMnemonicJAM,
+			unofficial:  true,
+		},
+	}
+}
+
+// 0x22: JAM
+func NewUnJAM2() *JAM {
+	return &JAM{
+		baseOperation{
+			code:        OpCodeUnJAM2,
+			addressMode: AddrModeImplied,
+			mnemonic:    OpMnemonicJAM,
+			unofficial:  true,
+		},
+	}
+}
+
+// 0x32: JAM
+func NewUnJAM3() *JAM {
+	return &JAM{
+		baseOperation{
+			code:        OpCodeUnJAM3,
+			addressMode: AddrModeImplied,
+			mnemonic:    OpMnemonicJAM,
+			unofficial:  true,
+		},
+	}
+}
+
+// 0x42: JAM
+func NewUnJAM4() *JAM {
+	return &JAM{
+		baseOperation{
+			code:        OpCodeUnJAM4,
+			addressMode: AddrModeImplied,
+			mnemonic:    OpMnemonicJAM,
+			unofficial:  true,
+		},
+	}
+}
+
+// 0x52: JAM
+func NewUnJAM5() *JAM {
+	return &JAM{
+		baseOperation{
+			code:        OpCodeUnJAM5,
+			addressMode: AddrModeImplied,
+			mnemonic:    OpMnemonicJAM,
+			unofficial:  true,
+		},
+	}
+}
+
+// 0x62: JAM
+func NewUnJAM6() *JAM {
+	return &JAM{
+		baseOperation{
+			code:        OpCodeUnJAM6,
+			addressMode: AddrModeImplied,
+			mnemonic:    OpMnemonicJAM,
+			unofficial:  true,
+		},
+	}
+}
+
+// 0x72: JAM
+func NewUnJAM7() *JAM {
+	return &JAM{
+		baseOperation{
+			code:        OpCodeUnJAM7,
+			addressMode: AddrModeImplied,
+			mnemonic:    OpMnemonicJAM,
+			unofficial:  true,
+		},
+	}
+}
+
+// 0x92: JAM
+func NewUnJAM8() *JAM {
+	return &JAM{
+		baseOperation{
+			code:        OpCodeUnJAM8,
+			addressMode: AddrModeImplied,
+			mnemonic:    OpMnemonicJAM,
+			unofficial:  true,
+		},
+	}
+}
+
+// 0xB2: JAM
+func NewUnJAM9() *JAM {
+	return &JAM{
+		baseOperation{
+			code:        OpCodeUnJAM9,
+			addressMode: AddrModeImplied,
+			mnemonic:    OpMnemonicJAM,
+			unofficial:  true,
+		},
+	}
+}
+
+// 0xD2: JAM
+func NewUnJAM10() *JAM {
+	return &JAM{
+		baseOperation{
+			code:        OpCodeUnJAM10,
+			addressMode: AddrModeImplied,
+			mnemonic:    OpMnemonicJAM,
+			unofficial:  true,
+		},
+	}
+}
+
+// 0xF2: JAM
+func NewUnJAM11() *JAM {
+	return &JAM{
+		baseOperation{
+			code:        OpCodeUnJAM11,
+			addressMode: AddrModeImplied,
+			mnemonic:    OpMnemonicJAM,
+			unofficial:  true,
+		},
+	}
+}
+
+func executeJAM(op Operation, env OperationEnvironment) (uint8, error) {
+	cycles := op.Cycles()
+
+	// the CPU locks up and stops fetching operations until it's reset; the
+	// environment decides how to handle it
+	if err := env.Jam(op); err != nil {
+		return cycles, err
+	}
+
+	return cycles, nil
+}
@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicJSR = "JSR"
 
@@ -33,50 +25,12 @@ func NewJSR(absoluteAddress uint16) *JSR {
 			code:        OpCodeJSR,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicJSR,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewJSRBinary(opCode uint8, data io.Reader) (*JSR, error) {
-	switch opCode {
-	case OpCodeJSR:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewJSR(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewJSRFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*JSR, error) {
-	switch addrMode {
-	case AddrModeAbsolute:
-		return NewJSR(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op JSR) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeAbsolute:
-		return 6
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op JSR) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeJSR(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	address, _, _ := env.FetchOperand(op)
 
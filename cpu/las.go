@@ -0,0 +1,54 @@
+package cpu
+
+const (
+	OpMnemonicLAS = "LAS"
+
+	// unofficial opcodes
+	OpCodeUnLASAbsoluteY = 0xBB
+)
+
+func IsOpCodeValidLAS(opCode uint8) bool {
+	return opCode == OpCodeUnLASAbsoluteY
+}
+
+func IsMnemonicValidLAS(mnemonic string) bool {
+	return mnemonic == OpMnemonicLAS
+}
+
+type LAS struct {
+	baseOperation
+}
+
+// 0xBB: LAS $NNNN, Y
+func NewUnLASAbsoluteY(absoluteAddress uint16) *LAS {
+	return &LAS{
+		baseOperation{
+			code:        OpCodeUnLASAbsoluteY,
+			addressMode: AddrModeAbsoluteY,
+			mnemonic:    OpMnemonicLAS,
+			args:        breakWordIntoArgs(absoluteAddress),
+			unofficial:  true,
+		},
+	}
+}
+
+func executeLAS(op Operation, env OperationEnvironment) (uint8, error) {
+	cycles := op.Cycles()
+	_, operand, pageCrossed := env.FetchOperand(op)
+
+	if pageCrossed {
+		cycles++
+	}
+
+	result := operand & env.GetStackPointer()
+	env.SetAccumulator(result)
+	env.SetIndexX(result)
+	env.SetStackPointer(result)
+
+	env.SetStatusZero(result == 0x00)
+	env.SetStatusNegative(result&0x80 != 0x00)
+
+	env.IncrementProgramCounter(op.Size())
+
+	return cycles, nil
+}
@@ -1,19 +1,12 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicLAX = "LAX"
 
 	// unofficial opcodes
 	OpCodeUnLAXIndirectX = 0xA3
 	OpCodeUnLAXZero      = 0xA7
+	OpCodeUnLAXImmediate = 0xAB
 	OpCodeUnLAXAbsolute  = 0xAF
 	OpCodeUnLAXIndirectY = 0xB3
 	OpCodeUnLAXZeroY     = 0xB7
@@ -23,6 +16,7 @@ const (
 func IsOpCodeValidLAX(opCode uint8) bool {
 	return opCode == OpCodeUnLAXIndirectX ||
 		opCode == OpCodeUnLAXZero ||
+		opCode == OpCodeUnLAXImmediate ||
 		opCode == OpCodeUnLAXAbsolute ||
 		opCode == OpCodeUnLAXIndirectY ||
 		opCode == OpCodeUnLAXZeroY ||
@@ -44,7 +38,7 @@ func NewUnLAXIndirectX(indirectAddress uint8) *LAX {
 			code:        OpCodeUnLAXIndirectX,
 			addressMode: AddrModeIndirectX,
 			mnemonic:    OpMnemonicLAX,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 			unofficial:  true,
 		},
 	}
@@ -57,7 +51,20 @@ func NewUnLAXZero(zeroAddress uint8) *LAX {
 			code:        OpCodeUnLAXZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicLAX,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
+			unofficial:  true,
+		},
+	}
+}
+
+// 0xAB: LAX #$NN
+func NewUnLAXImmediate(value uint8) *LAX {
+	return &LAX{
+		baseOperation{
+			code:        OpCodeUnLAXImmediate,
+			addressMode: AddrModeImmediate,
+			mnemonic:    OpMnemonicLAX,
+			args:        [2]uint8{value},
 			unofficial:  true,
 		},
 	}
@@ -70,7 +77,7 @@ func NewUnLAXAbsolute(absoluteAddress uint16) *LAX {
 			code:        OpCodeUnLAXAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicLAX,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -83,7 +90,7 @@ func NewUnLAXIndirectY(indirectAddress uint8) *LAX {
 			code:        OpCodeUnLAXIndirectY,
 			addressMode: AddrModeIndirectY,
 			mnemonic:    OpMnemonicLAX,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 			unofficial:  true,
 		},
 	}
@@ -96,7 +103,7 @@ func NewUnLAXZeroY(zeroAddress uint8) *LAX {
 			code:        OpCodeUnLAXZeroY,
 			addressMode: AddrModeZeroY,
 			mnemonic:    OpMnemonicLAX,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -109,111 +116,13 @@ func NewUnLAXAbsoluteY(absoluteAddress uint16) *LAX {
 			code:        OpCodeUnLAXAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicLAX,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
 }
 
-func NewLAXBinary(opCode uint8, data io.Reader) (*LAX, error) {
-	switch opCode {
-	case OpCodeUnLAXIndirectX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnLAXIndirectX(addr), nil
-	case OpCodeUnLAXZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnLAXZero(addr), nil
-	case OpCodeUnLAXAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnLAXAbsolute(addr), nil
-	case OpCodeUnLAXIndirectY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnLAXIndirectY(addr), nil
-	case OpCodeUnLAXZeroY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnLAXZeroY(addr), nil
-	case OpCodeUnLAXAbsoluteY:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnLAXAbsoluteY(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewLAXFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*LAX, error) {
-	switch addrMode {
-	case AddrModeIndirectX:
-		return NewUnLAXIndirectX(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewUnLAXZero(arg0), nil
-	case AddrModeAbsolute:
-		return NewUnLAXAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeIndirectY:
-		return NewUnLAXIndirectY(arg0), nil
-	case AddrModeZeroY:
-		return NewUnLAXZeroY(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewUnLAXAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op LAX) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeIndirectX:
-		return 6
-	case AddrModeZero:
-		return 3
-	case AddrModeAbsolute:
-		return 4
-	case AddrModeIndirectY:
-		return 5
-	case AddrModeZeroY:
-		return 4
-	case AddrModeAbsoluteY:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op LAX) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeLAX(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	_, operand, pageCrossed := env.FetchOperand(op)
 
@@ -221,6 +130,11 @@ func (op LAX) ExecuteIn(env OperationEnvironment) (uint8, error) {
 		cycles++
 	}
 
+	if op.AddressMode() == AddrModeImmediate {
+		// unlike the other address modes, the immediate one is unstable
+		operand &= env.GetAccumulator() | unstableMagicConstant
+	}
+
 	env.SetAccumulator(operand)
 	env.SetIndexX(operand)
 
@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicLDA = "LDA"
 
@@ -47,7 +39,7 @@ func NewLDAIndirectX(indirectAddress uint8) *LDA {
 			code:        OpCodeLDAIndirectX,
 			addressMode: AddrModeIndirectX,
 			mnemonic:    OpMnemonicLDA,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -59,7 +51,7 @@ func NewLDAZero(zeroAddress uint8) *LDA {
 			code:        OpCodeLDAZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicLDA,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -71,7 +63,7 @@ func NewLDAImmediate(value uint8) *LDA {
 			code:        OpCodeLDAImmediate,
 			addressMode: AddrModeImmediate,
 			mnemonic:    OpMnemonicLDA,
-			args:        []uint8{value},
+			args:        [2]uint8{value},
 		},
 	}
 }
@@ -83,7 +75,7 @@ func NewLDAAbsolute(absoluteAddress uint16) *LDA {
 			code:        OpCodeLDAAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicLDA,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -95,7 +87,7 @@ func NewLDAIndirectY(indirectAddress uint8) *LDA {
 			code:        OpCodeLDAIndirectY,
 			addressMode: AddrModeIndirectY,
 			mnemonic:    OpMnemonicLDA,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -107,7 +99,7 @@ func NewLDAZeroX(zeroAddress uint8) *LDA {
 			code:        OpCodeLDAZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicLDA,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -119,7 +111,7 @@ func NewLDAAbsoluteY(absoluteAddress uint16) *LDA {
 			code:        OpCodeLDAAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicLDA,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -131,134 +123,12 @@ func NewLDAAbsoluteX(absoluteAddress uint16) *LDA {
 			code:        OpCodeLDAAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicLDA,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewLDABinary(opCode uint8, data io.Reader) (*LDA, error) {
-	switch opCode {
-	case OpCodeLDAIndirectX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDAIndirectX(addr), nil
-	case OpCodeLDAZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDAZero(addr), nil
-	case OpCodeLDAImmediate:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDAImmediate(addr), nil
-	case OpCodeLDAAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDAAbsolute(addr), nil
-	case OpCodeLDAIndirectY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDAIndirectY(addr), nil
-	case OpCodeLDAZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDAZeroX(addr), nil
-	case OpCodeLDAAbsoluteY:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDAAbsoluteY(addr), nil
-	case OpCodeLDAAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDAAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewLDAFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*LDA, error) {
-	switch addrMode {
-	case AddrModeIndirectX:
-		return NewLDAIndirectX(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewLDAZero(arg0), nil
-	case AddrModeImmediate:
-		return NewLDAImmediate(arg0), nil
-	case AddrModeAbsolute:
-		return NewLDAAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeIndirectY:
-		return NewLDAIndirectY(arg0), nil
-	case AddrModeZeroX:
-		return NewLDAZeroX(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewLDAAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeAbsoluteX:
-		return NewLDAAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op LDA) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeIndirectX:
-		return 6
-	case AddrModeZero:
-		return 3
-	case AddrModeImmediate:
-		return 2
-	case AddrModeAbsolute:
-		return 4
-	case AddrModeIndirectY:
-		return 5
-	case AddrModeZeroX:
-		return 4
-	case AddrModeAbsoluteY:
-		return 4
-	case AddrModeAbsoluteX:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op LDA) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeLDA(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	_, operand, pageCrossed := env.FetchOperand(op)
 
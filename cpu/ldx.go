@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicLDX = "LDX"
 
@@ -40,7 +32,7 @@ func NewLDXImmediate(value uint8) *LDX {
 			code:        OpCodeLDXImmediate,
 			addressMode: AddrModeImmediate,
 			mnemonic:    OpMnemonicLDX,
-			args:        []uint8{value},
+			args:        [2]uint8{value},
 		},
 	}
 }
@@ -51,7 +43,7 @@ func NewLDXZero(zeroAddress uint8) *LDX {
 			code:        OpCodeLDXZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicLDX,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -62,7 +54,7 @@ func NewLDXAbsolute(absoluteAddress uint16) *LDX {
 			code:        OpCodeLDXAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicLDX,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -73,7 +65,7 @@ func NewLDXZeroY(zeroAddress uint8) *LDX {
 			code:        OpCodeLDXZeroY,
 			addressMode: AddrModeZeroY,
 			mnemonic:    OpMnemonicLDX,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -84,98 +76,12 @@ func NewLDXAbsoluteY(absoluteAddress uint16) *LDX {
 			code:        OpCodeLDXAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicLDX,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewLDXBinary(opCode uint8, data io.Reader) (*LDX, error) {
-	switch opCode {
-	case OpCodeLDXImmediate:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDXImmediate(addr), nil
-	case OpCodeLDXZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDXZero(addr), nil
-	case OpCodeLDXAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDXAbsolute(addr), nil
-	case OpCodeLDXZeroY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDXZeroY(addr), nil
-	case OpCodeLDXAbsoluteY:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDXAbsoluteY(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewLDXFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*LDX, error) {
-	switch addrMode {
-	case AddrModeImmediate:
-		return NewLDXImmediate(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewLDXZero(arg0), nil
-	case AddrModeAbsolute:
-		return NewLDXAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeZeroY:
-		return NewLDXZeroY(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewLDXAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op LDX) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeZero:
-		return 3
-	case AddrModeImmediate:
-		return 2
-	case AddrModeAbsolute:
-		return 4
-	case AddrModeZeroY:
-		return 4
-	case AddrModeAbsoluteY:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op LDX) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeLDX(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	_, operand, pageCrossed := env.FetchOperand(op)
 
@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicLDY = "LDY"
 
@@ -40,7 +32,7 @@ func NewLDYImmediate(value uint8) *LDY {
 			code:        OpCodeLDYImmediate,
 			addressMode: AddrModeImmediate,
 			mnemonic:    OpMnemonicLDY,
-			args:        []uint8{value},
+			args:        [2]uint8{value},
 		},
 	}
 }
@@ -51,7 +43,7 @@ func NewLDYZero(zeroAddress uint8) *LDY {
 			code:        OpCodeLDYZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicLDY,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -62,7 +54,7 @@ func NewLDYAbsolute(absoluteAddress uint16) *LDY {
 			code:        OpCodeLDYAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicLDY,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -73,7 +65,7 @@ func NewLDYZeroX(zeroAddress uint8) *LDY {
 			code:        OpCodeLDYZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicLDY,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -84,95 +76,12 @@ func NewLDYAbsoluteX(absoluteAddress uint16) *LDY {
 			code:        OpCodeLDYAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicLDY,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewLDYBinary(opCode uint8, data io.Reader) (*LDY, error) {
-	switch opCode {
-	case OpCodeLDYImmediate:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDYImmediate(addr), nil
-	case OpCodeLDYZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDYZero(addr), nil
-	case OpCodeLDYAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDYAbsolute(addr), nil
-	case OpCodeLDYZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDYZeroX(addr), nil
-	case OpCodeLDYAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLDYAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{opCode}
-	}
-}
-
-func NewLDYFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*LDY, error) {
-	switch addrMode {
-	case AddrModeImmediate:
-		return NewLDYImmediate(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewLDYZero(arg0), nil
-	case AddrModeAbsolute:
-		return NewLDYAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeZeroX:
-		return NewLDYZeroX(arg0), nil
-	case AddrModeAbsoluteX:
-		return NewLDYAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op LDY) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeZero:
-		return 3
-	case AddrModeImmediate:
-		return 2
-	case AddrModeAbsolute:
-		return 4
-	case AddrModeZeroX:
-		return 4
-	case AddrModeAbsoluteX:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-func (op LDY) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeLDY(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	_, operand, pageCrossed := env.FetchOperand(op)
 
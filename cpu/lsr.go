@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicLSR = "LSR"
 
@@ -52,7 +44,7 @@ func NewLSRZero(zeroAddress uint8) *LSR {
 			code:        OpCodeLSRZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicLSR,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -64,7 +56,7 @@ func NewLSRZeroX(zeroAddress uint8) *LSR {
 			code:        OpCodeLSRZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicLSR,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -76,7 +68,7 @@ func NewLSRAbsolute(absoluteAddress uint16) *LSR {
 			code:        OpCodeLSRAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicLSR,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -88,92 +80,12 @@ func NewLSRAbsoluteX(absoluteAddress uint16) *LSR {
 			code:        OpCodeLSRAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicLSR,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewLSRBinary(opCode uint8, data io.Reader) (*LSR, error) {
-	switch opCode {
-	case OpCodeLSRZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLSRZero(addr), nil
-	case OpCodeLSRAccumulator:
-		return NewLSRAccumulator(), nil
-	case OpCodeLSRAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLSRAbsolute(addr), nil
-	case OpCodeLSRZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLSRZeroX(addr), nil
-	case OpCodeLSRAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewLSRAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewLSRFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*LSR, error) {
-	switch addrMode {
-	case AddrModeZero, AddrModeRelative:
-		return NewLSRZero(arg0), nil
-	case AddrModeAccumulator:
-		return NewLSRAccumulator(), nil
-	case AddrModeAbsolute:
-		return NewLSRAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeZeroX:
-		return NewLSRZeroX(arg0), nil
-	case AddrModeAbsoluteX:
-		return NewLSRAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op LSR) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeZero:
-		return 5
-	case AddrModeAccumulator:
-		return 2
-	case AddrModeAbsolute:
-		return 6
-	case AddrModeZeroX:
-		return 6
-	case AddrModeAbsoluteX:
-		return 7
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op LSR) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeLSR(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	address, operand, _ := env.FetchOperand(op)
 
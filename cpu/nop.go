@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicNOP = "NOP"
 
@@ -31,9 +23,13 @@ const (
 	OpCodeUnNOPImplied3   = 0x7A
 	OpCodeUnNOPAbsoluteX3 = 0x7C
 	OpCodeUnNOPImmediate  = 0x80
+	OpCodeUnNOPImmediate1 = 0x82
+	OpCodeUnNOPImmediate2 = 0x89
+	OpCodeUnNOPImmediate3 = 0xC2
 	OpCodeUnNOPZeroX4     = 0xD4
 	OpCodeUnNOPImplied4   = 0xDA
 	OpCodeUnNOPAbsoluteX4 = 0xDC
+	OpCodeUnNOPImmediate4 = 0xE2
 	OpCodeUnNOPZeroX5     = 0xF4
 	OpCodeUnNOPImplied5   = 0xFA
 	OpCodeUnNOPAbsoluteX5 = 0xFC
@@ -58,9 +54,13 @@ func IsOpCodeValidNOP(opCode uint8) bool {
 		opCode == OpCodeUnNOPImplied3 ||
 		opCode == OpCodeUnNOPAbsoluteX3 ||
 		opCode == OpCodeUnNOPImmediate ||
+		opCode == OpCodeUnNOPImmediate1 ||
+		opCode == OpCodeUnNOPImmediate2 ||
+		opCode == OpCodeUnNOPImmediate3 ||
 		opCode == OpCodeUnNOPZeroX4 ||
 		opCode == OpCodeUnNOPImplied4 ||
 		opCode == OpCodeUnNOPAbsoluteX4 ||
+		opCode == OpCodeUnNOPImmediate4 ||
 		opCode == OpCodeUnNOPZeroX5 ||
 		opCode == OpCodeUnNOPImplied5 ||
 		opCode == OpCodeUnNOPAbsoluteX5
@@ -92,7 +92,7 @@ func NewUnNOPZero0(zeroAddress uint8) *NOP {
 			code:        OpCodeUnNOPZero0,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicNOP,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -105,7 +105,7 @@ func NewUnNOPAbsolute(absoluteAddress uint16) *NOP {
 			code:        OpCodeUnNOPAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicNOP,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -118,7 +118,7 @@ func NewUnNOPZeroX0(zeroAddress uint8) *NOP {
 			code:        OpCodeUnNOPZeroX0,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicNOP,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -143,7 +143,7 @@ func NewUnNOPAbsoluteX0(absoluteAddress uint16) *NOP {
 			code:        OpCodeUnNOPAbsoluteX0,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicNOP,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -156,7 +156,7 @@ func NewUnNOPZeroX1(zeroAddress uint8) *NOP {
 			code:        OpCodeUnNOPZeroX1,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicNOP,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -181,7 +181,7 @@ func NewUnNOPAbsoluteX1(absoluteAddress uint16) *NOP {
 			code:        OpCodeUnNOPAbsoluteX1,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicNOP,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -194,7 +194,7 @@ func NewUnNOPZero1(zeroAddress uint8) *NOP {
 			code:        OpCodeUnNOPZero1,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicNOP,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -207,7 +207,7 @@ func NewUnNOPZeroX2(zeroAddress uint8) *NOP {
 			code:        OpCodeUnNOPZeroX2,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicNOP,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -232,7 +232,7 @@ func NewUnNOPAbsoluteX2(absoluteAddress uint16) *NOP {
 			code:        OpCodeUnNOPAbsoluteX2,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicNOP,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -245,7 +245,7 @@ func NewUnNOPZero2(zeroAddress uint8) *NOP {
 			code:        OpCodeUnNOPZero2,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicNOP,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -258,7 +258,7 @@ func NewUnNOPZeroX3(zeroAddress uint8) *NOP {
 			code:        OpCodeUnNOPZeroX3,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicNOP,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -283,7 +283,7 @@ func NewUnNOPAbsoluteX3(absoluteAddress uint16) *NOP {
 			code:        OpCodeUnNOPAbsoluteX3,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicNOP,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -296,7 +296,46 @@ func NewUnNOPImmediate(value uint8) *NOP {
 			code:        OpCodeUnNOPImmediate,
 			addressMode: AddrModeImmediate,
 			mnemonic:    OpMnemonicNOP,
-			args:        []uint8{value},
+			args:        [2]uint8{value},
+			unofficial:  true,
+		},
+	}
+}
+
+// 0x82: NOP #$NN
+func NewUnNOPImmediate1(value uint8) *NOP {
+	return &NOP{
+		baseOperation{
+			code:        OpCodeUnNOPImmediate1,
+			addressMode: AddrModeImmediate,
+			mnemonic:    OpMnemonicNOP,
+			args:        [2]uint8{value},
+			unofficial:  true,
+		},
+	}
+}
+
+// 0x89: NOP #$NN
+func NewUnNOPImmediate2(value uint8) *NOP {
+	return &NOP{
+		baseOperation{
+			code:        OpCodeUnNOPImmediate2,
+			addressMode: AddrModeImmediate,
+			mnemonic:    OpMnemonicNOP,
+			args:        [2]uint8{value},
+			unofficial:  true,
+		},
+	}
+}
+
+// 0xC2: NOP #$NN
+func NewUnNOPImmediate3(value uint8) *NOP {
+	return &NOP{
+		baseOperation{
+			code:        OpCodeUnNOPImmediate3,
+			addressMode: AddrModeImmediate,
+			mnemonic:    OpMnemonicNOP,
+			args:        [2]uint8{value},
 			unofficial:  true,
 		},
 	}
@@ -309,7 +348,7 @@ func NewUnNOPZeroX4(zeroAddress uint8) *NOP {
 			code:        OpCodeUnNOPZeroX4,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicNOP,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -334,7 +373,20 @@ func NewUnNOPAbsoluteX4(absoluteAddress uint16) *NOP {
 			code:        OpCodeUnNOPAbsoluteX4,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicNOP,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
+			unofficial:  true,
+		},
+	}
+}
+
+// 0xE2: NOP #$NN
+func NewUnNOPImmediate4(value uint8) *NOP {
+	return &NOP{
+		baseOperation{
+			code:        OpCodeUnNOPImmediate4,
+			addressMode: AddrModeImmediate,
+			mnemonic:    OpMnemonicNOP,
+			args:        [2]uint8{value},
 			unofficial:  true,
 		},
 	}
@@ -347,7 +399,7 @@ func NewUnNOPZeroX5(zeroAddress uint8) *NOP {
 			code:        OpCodeUnNOPZeroX5,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicNOP,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -372,213 +424,13 @@ func NewUnNOPAbsoluteX5(absoluteAddress uint16) *NOP {
 			code:        OpCodeUnNOPAbsoluteX5,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicNOP,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
 }
 
-func NewNOPBinary(opCode uint8, data io.Reader) (*NOP, error) {
-	switch opCode {
-	case OpCodeNOP:
-		return NewNOP(), nil
-	case OpCodeUnNOPZero0:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPZero0(addr), nil
-	case OpCodeUnNOPAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPAbsolute(addr), nil
-	case OpCodeUnNOPZeroX0:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPZeroX0(addr), nil
-	case OpCodeUnNOPImplied0:
-		return NewUnNOPImplied0(), nil
-	case OpCodeUnNOPAbsoluteX0:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPAbsoluteX0(addr), nil
-	case OpCodeUnNOPZeroX1:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPZeroX1(addr), nil
-	case OpCodeUnNOPImplied1:
-		return NewUnNOPImplied1(), nil
-	case OpCodeUnNOPAbsoluteX1:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPAbsoluteX1(addr), nil
-	case OpCodeUnNOPZero1:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPZero1(addr), nil
-	case OpCodeUnNOPZeroX2:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPZeroX2(addr), nil
-	case OpCodeUnNOPImplied2:
-		return NewUnNOPImplied2(), nil
-	case OpCodeUnNOPAbsoluteX2:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPAbsoluteX2(addr), nil
-	case OpCodeUnNOPZero2:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPZero2(addr), nil
-	case OpCodeUnNOPZeroX3:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPZeroX3(addr), nil
-	case OpCodeUnNOPImplied3:
-		return NewUnNOPImplied3(), nil
-	case OpCodeUnNOPAbsoluteX3:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPAbsoluteX3(addr), nil
-	case OpCodeUnNOPImmediate:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPImmediate(addr), nil
-	case OpCodeUnNOPZeroX4:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPZeroX4(addr), nil
-	case OpCodeUnNOPImplied4:
-		return NewUnNOPImplied4(), nil
-	case OpCodeUnNOPAbsoluteX4:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPAbsoluteX4(addr), nil
-	case OpCodeUnNOPZeroX5:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPZeroX5(addr), nil
-	case OpCodeUnNOPImplied5:
-		return NewUnNOPImplied5(), nil
-	case OpCodeUnNOPAbsoluteX5:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnNOPAbsoluteX5(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewNOPFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*NOP, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewNOP(), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewUnNOPZero0(arg0), nil
-	case AddrModeAbsolute:
-		return NewUnNOPAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeZeroX:
-		return NewUnNOPZeroX0(arg0), nil
-	case AddrModeAbsoluteX:
-		return NewUnNOPAbsoluteX0(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeImmediate:
-		return NewUnNOPImmediate(arg0), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op NOP) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	case AddrModeZero:
-		return 3
-	case AddrModeAbsolute:
-		return 4
-	case AddrModeZeroX:
-		return 4
-	case AddrModeAbsoluteX:
-		return 4
-	case AddrModeImmediate:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op NOP) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeNOP(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	_, _, pageCrossed := env.FetchOperand(op)
 
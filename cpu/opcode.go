@@ -0,0 +1,408 @@
+package cpu
+
+// MemoryAccess describes what an operation does with the memory location
+// given by its address mode.
+type MemoryAccess uint8
+
+const (
+	// the address is used by itself (e.g. JMP), or there's no address
+	MemoryAccessNone MemoryAccess = iota
+	MemoryAccessRead
+	MemoryAccessWrite
+	// the value is read, modified and then written back
+	MemoryAccessReadModifyWrite
+)
+
+// OpCodeInfo describes how an op code is decoded and executed.
+type OpCodeInfo struct {
+	Mnemonic    string
+	AddressMode uint8
+	Access      MemoryAccess
+	// number of cycles taken by the operation, without the extra cycles
+	// from crossing pages or taking branches
+	Cycles uint8
+	// whether the operation takes one more cycle when the indexed address
+	// crosses a page boundary
+	PageCrossPenalty bool
+	Unofficial       bool
+
+	execute func(Operation, OperationEnvironment) (uint8, error)
+}
+
+// IsValid checks if the op code is implemented.
+func (info OpCodeInfo) IsValid() bool {
+	return info.execute != nil
+}
+
+// Size returns the number of bytes taken by the operation, including the op
+// code itself.
+func (info OpCodeInfo) Size() uint8 {
+	return addressModeSize(info.AddressMode)
+}
+
+func addressModeSize(addressMode uint8) uint8 {
+	switch addressMode {
+	case AddrModeAccumulator, AddrModeImplied:
+		return 1
+	case AddrModeImmediate, AddrModeRelative, AddrModeZero, AddrModeZeroX, AddrModeZeroY, AddrModeIndirectX, AddrModeIndirectY:
+		return 2
+	case AddrModeAbsolute, AddrModeIndirect, AddrModeAbsoluteX, AddrModeAbsoluteY:
+		return 3
+	default:
+		return 1
+	}
+}
+
+// OpCodes describes every op code understood by the CPU, indexed by the op
+// code itself.
+var OpCodes = [256]OpCodeInfo{
+	OpCodeBRK:             {Mnemonic: OpMnemonicBRK, AddressMode: AddrModeImplied, Cycles: 7, execute: executeBRK},
+	OpCodeORAIndirectX:    {Mnemonic: OpMnemonicORA, AddressMode: AddrModeIndirectX, Cycles: 6, execute: executeORA},
+	OpCodeUnJAM0:          {Mnemonic: OpMnemonicJAM, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeJAM},
+	OpCodeUnSLOIndirectX:  {Mnemonic: OpMnemonicSLO, AddressMode: AddrModeIndirectX, Cycles: 8, Unofficial: true, execute: executeSLO},
+	OpCodeUnNOPZero0:      {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeZero, Cycles: 3, Unofficial: true, execute: executeNOP},
+	OpCodeORAZero:         {Mnemonic: OpMnemonicORA, AddressMode: AddrModeZero, Cycles: 3, execute: executeORA},
+	OpCodeASLZero:         {Mnemonic: OpMnemonicASL, AddressMode: AddrModeZero, Cycles: 5, execute: executeASL},
+	OpCodeUnSLOZero:       {Mnemonic: OpMnemonicSLO, AddressMode: AddrModeZero, Cycles: 5, Unofficial: true, execute: executeSLO},
+	OpCodePHP:             {Mnemonic: OpMnemonicPHP, AddressMode: AddrModeImplied, Cycles: 3, execute: executePHP},
+	OpCodeORAImmediate:    {Mnemonic: OpMnemonicORA, AddressMode: AddrModeImmediate, Cycles: 2, execute: executeORA},
+	OpCodeASLAccumulator:  {Mnemonic: OpMnemonicASL, AddressMode: AddrModeAccumulator, Cycles: 2, execute: executeASL},
+	OpCodeUnANCImmediate0: {Mnemonic: OpMnemonicANC, AddressMode: AddrModeImmediate, Cycles: 2, Unofficial: true, execute: executeANC},
+	OpCodeUnNOPAbsolute:   {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeAbsolute, Cycles: 4, Unofficial: true, execute: executeNOP},
+	OpCodeORAAbsolute:     {Mnemonic: OpMnemonicORA, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeORA},
+	OpCodeASLAbsolute:     {Mnemonic: OpMnemonicASL, AddressMode: AddrModeAbsolute, Cycles: 6, execute: executeASL},
+	OpCodeUnSLOAbsolute:   {Mnemonic: OpMnemonicSLO, AddressMode: AddrModeAbsolute, Cycles: 6, Unofficial: true, execute: executeSLO},
+	OpCodeBPL:             {Mnemonic: OpMnemonicBPL, AddressMode: AddrModeRelative, Cycles: 2, execute: executeBPL},
+	OpCodeORAIndirectY:    {Mnemonic: OpMnemonicORA, AddressMode: AddrModeIndirectY, Cycles: 5, PageCrossPenalty: true, execute: executeORA},
+	OpCodeUnJAM1:          {Mnemonic: OpMnemonicJAM, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeJAM},
+	OpCodeUnSLOIndirectY:  {Mnemonic: OpMnemonicSLO, AddressMode: AddrModeIndirectY, Cycles: 8, Unofficial: true, execute: executeSLO},
+	OpCodeUnNOPZeroX0:     {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeZeroX, Cycles: 4, Unofficial: true, execute: executeNOP},
+	OpCodeORAZeroX:        {Mnemonic: OpMnemonicORA, AddressMode: AddrModeZeroX, Cycles: 4, execute: executeORA},
+	OpCodeASLZeroX:        {Mnemonic: OpMnemonicASL, AddressMode: AddrModeZeroX, Cycles: 6, execute: executeASL},
+	OpCodeUnSLOZeroX:      {Mnemonic: OpMnemonicSLO, AddressMode: AddrModeZeroX, Cycles: 6, Unofficial: true, execute: executeSLO},
+	OpCodeCLC:             {Mnemonic: OpMnemonicCLC, AddressMode: AddrModeImplied, Cycles: 2, execute: executeCLC},
+	OpCodeORAAbsoluteY:    {Mnemonic: OpMnemonicORA, AddressMode: AddrModeAbsoluteY, Cycles: 4, PageCrossPenalty: true, execute: executeORA},
+	OpCodeUnNOPImplied0:   {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeNOP},
+	OpCodeUnSLOAbsoluteY:  {Mnemonic: OpMnemonicSLO, AddressMode: AddrModeAbsoluteY, Cycles: 7, Unofficial: true, execute: executeSLO},
+	OpCodeUnNOPAbsoluteX0: {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, Unofficial: true, execute: executeNOP},
+	OpCodeORAAbsoluteX:    {Mnemonic: OpMnemonicORA, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, execute: executeORA},
+	OpCodeASLAbsoluteX:    {Mnemonic: OpMnemonicASL, AddressMode: AddrModeAbsoluteX, Cycles: 7, execute: executeASL},
+	OpCodeUnSLOAbsoluteX:  {Mnemonic: OpMnemonicSLO, AddressMode: AddrModeAbsoluteX, Cycles: 7, Unofficial: true, execute: executeSLO},
+	OpCodeJSR:             {Mnemonic: OpMnemonicJSR, AddressMode: AddrModeAbsolute, Cycles: 6, execute: executeJSR},
+	OpCodeANDIndirectX:    {Mnemonic: OpMnemonicAND, AddressMode: AddrModeIndirectX, Cycles: 6, execute: executeAND},
+	OpCodeUnJAM2:          {Mnemonic: OpMnemonicJAM, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeJAM},
+	OpCodeUnRLAIndirectX:  {Mnemonic: OpMnemonicRLA, AddressMode: AddrModeIndirectX, Cycles: 8, Unofficial: true, execute: executeRLA},
+	OpCodeBITZero:         {Mnemonic: OpMnemonicBIT, AddressMode: AddrModeZero, Cycles: 3, execute: executeBIT},
+	OpCodeANDZero:         {Mnemonic: OpMnemonicAND, AddressMode: AddrModeZero, Cycles: 3, execute: executeAND},
+	OpCodeROLZero:         {Mnemonic: OpMnemonicROL, AddressMode: AddrModeZero, Cycles: 5, execute: executeROL},
+	OpCodeUnRLAZero:       {Mnemonic: OpMnemonicRLA, AddressMode: AddrModeZero, Cycles: 5, Unofficial: true, execute: executeRLA},
+	OpCodePLP:             {Mnemonic: OpMnemonicPLP, AddressMode: AddrModeImplied, Cycles: 4, execute: executePLP},
+	OpCodeANDImmediate:    {Mnemonic: OpMnemonicAND, AddressMode: AddrModeImmediate, Cycles: 2, execute: executeAND},
+	OpCodeROLAccumulator:  {Mnemonic: OpMnemonicROL, AddressMode: AddrModeAccumulator, Cycles: 2, execute: executeROL},
+	OpCodeUnANCImmediate1: {Mnemonic: OpMnemonicANC, AddressMode: AddrModeImmediate, Cycles: 2, Unofficial: true, execute: executeANC},
+	OpCodeBITAbsolute:     {Mnemonic: OpMnemonicBIT, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeBIT},
+	OpCodeANDAbsolute:     {Mnemonic: OpMnemonicAND, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeAND},
+	OpCodeROLAbsolute:     {Mnemonic: OpMnemonicROL, AddressMode: AddrModeAbsolute, Cycles: 6, execute: executeROL},
+	OpCodeUnRLAAbsolute:   {Mnemonic: OpMnemonicRLA, AddressMode: AddrModeAbsolute, Cycles: 6, Unofficial: true, execute: executeRLA},
+	OpCodeBMI:             {Mnemonic: OpMnemonicBMI, AddressMode: AddrModeRelative, Cycles: 2, execute: executeBMI},
+	OpCodeANDIndirectY:    {Mnemonic: OpMnemonicAND, AddressMode: AddrModeIndirectY, Cycles: 5, PageCrossPenalty: true, execute: executeAND},
+	OpCodeUnJAM3:          {Mnemonic: OpMnemonicJAM, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeJAM},
+	OpCodeUnRLAIndirectY:  {Mnemonic: OpMnemonicRLA, AddressMode: AddrModeIndirectY, Cycles: 8, Unofficial: true, execute: executeRLA},
+	OpCodeUnNOPZeroX1:     {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeZeroX, Cycles: 4, Unofficial: true, execute: executeNOP},
+	OpCodeANDZeroX:        {Mnemonic: OpMnemonicAND, AddressMode: AddrModeZeroX, Cycles: 4, execute: executeAND},
+	OpCodeROLZeroX:        {Mnemonic: OpMnemonicROL, AddressMode: AddrModeZeroX, Cycles: 6, execute: executeROL},
+	OpCodeUnRLAZeroX:      {Mnemonic: OpMnemonicRLA, AddressMode: AddrModeZeroX, Cycles: 6, Unofficial: true, execute: executeRLA},
+	OpCodeSEC:             {Mnemonic: OpMnemonicSEC, AddressMode: AddrModeImplied, Cycles: 2, execute: executeSEC},
+	OpCodeANDAbsoluteY:    {Mnemonic: OpMnemonicAND, AddressMode: AddrModeAbsoluteY, Cycles: 4, PageCrossPenalty: true, execute: executeAND},
+	OpCodeUnNOPImplied1:   {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeNOP},
+	OpCodeUnRLAAbsoluteY:  {Mnemonic: OpMnemonicRLA, AddressMode: AddrModeAbsoluteY, Cycles: 7, Unofficial: true, execute: executeRLA},
+	OpCodeUnNOPAbsoluteX1: {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, Unofficial: true, execute: executeNOP},
+	OpCodeANDAbsoluteX:    {Mnemonic: OpMnemonicAND, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, execute: executeAND},
+	OpCodeROLAbsoluteX:    {Mnemonic: OpMnemonicROL, AddressMode: AddrModeAbsoluteX, Cycles: 7, execute: executeROL},
+	OpCodeUnRLAAbsoluteX:  {Mnemonic: OpMnemonicRLA, AddressMode: AddrModeAbsoluteX, Cycles: 7, Unofficial: true, execute: executeRLA},
+	OpCodeRTI:             {Mnemonic: OpMnemonicRTI, AddressMode: AddrModeImplied, Cycles: 6, execute: executeRTI},
+	OpCodeEORIndirectX:    {Mnemonic: OpMnemonicEOR, AddressMode: AddrModeIndirectX, Cycles: 6, execute: executeEOR},
+	OpCodeUnJAM4:          {Mnemonic: OpMnemonicJAM, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeJAM},
+	OpCodeUnSREIndirectX:  {Mnemonic: OpMnemonicSRE, AddressMode: AddrModeIndirectX, Cycles: 8, Unofficial: true, execute: executeSRE},
+	OpCodeUnNOPZero1:      {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeZero, Cycles: 3, Unofficial: true, execute: executeNOP},
+	OpCodeEORZero:         {Mnemonic: OpMnemonicEOR, AddressMode: AddrModeZero, Cycles: 3, execute: executeEOR},
+	OpCodeLSRZero:         {Mnemonic: OpMnemonicLSR, AddressMode: AddrModeZero, Cycles: 5, execute: executeLSR},
+	OpCodeUnSREZero:       {Mnemonic: OpMnemonicSRE, AddressMode: AddrModeZero, Cycles: 5, Unofficial: true, execute: executeSRE},
+	OpCodePHA:             {Mnemonic: OpMnemonicPHA, AddressMode: AddrModeImplied, Cycles: 3, execute: executePHA},
+	OpCodeEORImmediate:    {Mnemonic: OpMnemonicEOR, AddressMode: AddrModeImmediate, Cycles: 2, execute: executeEOR},
+	OpCodeLSRAccumulator:  {Mnemonic: OpMnemonicLSR, AddressMode: AddrModeAccumulator, Cycles: 2, execute: executeLSR},
+	OpCodeUnALRImmediate:  {Mnemonic: OpMnemonicALR, AddressMode: AddrModeImmediate, Cycles: 2, Unofficial: true, execute: executeALR},
+	OpCodeJMPAbsolute:     {Mnemonic: OpMnemonicJMP, AddressMode: AddrModeAbsolute, Cycles: 3, execute: executeJMP},
+	OpCodeEORAbsolute:     {Mnemonic: OpMnemonicEOR, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeEOR},
+	OpCodeLSRAbsolute:     {Mnemonic: OpMnemonicLSR, AddressMode: AddrModeAbsolute, Cycles: 6, execute: executeLSR},
+	OpCodeUnSREAbsolute:   {Mnemonic: OpMnemonicSRE, AddressMode: AddrModeAbsolute, Cycles: 6, Unofficial: true, execute: executeSRE},
+	OpCodeBVC:             {Mnemonic: OpMnemonicBVC, AddressMode: AddrModeRelative, Cycles: 2, execute: executeBVC},
+	OpCodeEORIndirectY:    {Mnemonic: OpMnemonicEOR, AddressMode: AddrModeIndirectY, Cycles: 5, PageCrossPenalty: true, execute: executeEOR},
+	OpCodeUnJAM5:          {Mnemonic: OpMnemonicJAM, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeJAM},
+	OpCodeUnSREIndirectY:  {Mnemonic: OpMnemonicSRE, AddressMode: AddrModeIndirectY, Cycles: 8, Unofficial: true, execute: executeSRE},
+	OpCodeUnNOPZeroX2:     {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeZeroX, Cycles: 4, Unofficial: true, execute: executeNOP},
+	OpCodeEORZeroX:        {Mnemonic: OpMnemonicEOR, AddressMode: AddrModeZeroX, Cycles: 4, execute: executeEOR},
+	OpCodeLSRZeroX:        {Mnemonic: OpMnemonicLSR, AddressMode: AddrModeZeroX, Cycles: 6, execute: executeLSR},
+	OpCodeUnSREZeroX:      {Mnemonic: OpMnemonicSRE, AddressMode: AddrModeZeroX, Cycles: 6, Unofficial: true, execute: executeSRE},
+	OpCodeCLI:             {Mnemonic: OpMnemonicCLI, AddressMode: AddrModeImplied, Cycles: 2, execute: executeCLI},
+	OpCodeEORAbsoluteY:    {Mnemonic: OpMnemonicEOR, AddressMode: AddrModeAbsoluteY, Cycles: 4, PageCrossPenalty: true, execute: executeEOR},
+	OpCodeUnNOPImplied2:   {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeNOP},
+	OpCodeUnSREAbsoluteY:  {Mnemonic: OpMnemonicSRE, AddressMode: AddrModeAbsoluteY, Cycles: 7, Unofficial: true, execute: executeSRE},
+	OpCodeUnNOPAbsoluteX2: {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, Unofficial: true, execute: executeNOP},
+	OpCodeEORAbsoluteX:    {Mnemonic: OpMnemonicEOR, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, execute: executeEOR},
+	OpCodeLSRAbsoluteX:    {Mnemonic: OpMnemonicLSR, AddressMode: AddrModeAbsoluteX, Cycles: 7, execute: executeLSR},
+	OpCodeUnSREAbsoluteX:  {Mnemonic: OpMnemonicSRE, AddressMode: AddrModeAbsoluteX, Cycles: 7, Unofficial: true, execute: executeSRE},
+	OpCodeRTS:             {Mnemonic: OpMnemonicRTS, AddressMode: AddrModeImplied, Cycles: 6, execute: executeRTS},
+	OpCodeADCIndirectX:    {Mnemonic: OpMnemonicADC, AddressMode: AddrModeIndirectX, Cycles: 6, execute: executeADC},
+	OpCodeUnJAM6:          {Mnemonic: OpMnemonicJAM, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeJAM},
+	OpCodeUnRRAIndirectX:  {Mnemonic: OpMnemonicRRA, AddressMode: AddrModeIndirectX, Cycles: 8, Unofficial: true, execute: executeRRA},
+	OpCodeUnNOPZero2:      {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeZero, Cycles: 3, Unofficial: true, execute: executeNOP},
+	OpCodeADCZero:         {Mnemonic: OpMnemonicADC, AddressMode: AddrModeZero, Cycles: 3, execute: executeADC},
+	OpCodeRORZero:         {Mnemonic: OpMnemonicROR, AddressMode: AddrModeZero, Cycles: 5, execute: executeROR},
+	OpCodeUnRRAZero:       {Mnemonic: OpMnemonicRRA, AddressMode: AddrModeZero, Cycles: 5, Unofficial: true, execute: executeRRA},
+	OpCodePLA:             {Mnemonic: OpMnemonicPLA, AddressMode: AddrModeImplied, Cycles: 4, execute: executePLA},
+	OpCodeADCImmediate:    {Mnemonic: OpMnemonicADC, AddressMode: AddrModeImmediate, Cycles: 2, execute: executeADC},
+	OpCodeRORAccumulator:  {Mnemonic: OpMnemonicROR, AddressMode: AddrModeAccumulator, Cycles: 2, execute: executeROR},
+	OpCodeUnARRImmediate:  {Mnemonic: OpMnemonicARR, AddressMode: AddrModeImmediate, Cycles: 2, Unofficial: true, execute: executeARR},
+	OpCodeJMPIndirect:     {Mnemonic: OpMnemonicJMP, AddressMode: AddrModeIndirect, Cycles: 5, execute: executeJMP},
+	OpCodeADCAbsolute:     {Mnemonic: OpMnemonicADC, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeADC},
+	OpCodeRORAbsolute:     {Mnemonic: OpMnemonicROR, AddressMode: AddrModeAbsolute, Cycles: 6, execute: executeROR},
+	OpCodeUnRRAAbsolute:   {Mnemonic: OpMnemonicRRA, AddressMode: AddrModeAbsolute, Cycles: 6, Unofficial: true, execute: executeRRA},
+	OpCodeBVS:             {Mnemonic: OpMnemonicBVS, AddressMode: AddrModeRelative, Cycles: 2, execute: executeBVS},
+	OpCodeADCIndirectY:    {Mnemonic: OpMnemonicADC, AddressMode: AddrModeIndirectY, Cycles: 5, PageCrossPenalty: true, execute: executeADC},
+	OpCodeUnJAM7:          {Mnemonic: OpMnemonicJAM, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeJAM},
+	OpCodeUnRRAIndirectY:  {Mnemonic: OpMnemonicRRA, AddressMode: AddrModeIndirectY, Cycles: 8, Unofficial: true, execute: executeRRA},
+	OpCodeUnNOPZeroX3:     {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeZeroX, Cycles: 4, Unofficial: true, execute: executeNOP},
+	OpCodeADCZeroX:        {Mnemonic: OpMnemonicADC, AddressMode: AddrModeZeroX, Cycles: 4, execute: executeADC},
+	OpCodeRORZeroX:        {Mnemonic: OpMnemonicROR, AddressMode: AddrModeZeroX, Cycles: 6, execute: executeROR},
+	OpCodeUnRRAZeroX:      {Mnemonic: OpMnemonicRRA, AddressMode: AddrModeZeroX, Cycles: 6, Unofficial: true, execute: executeRRA},
+	OpCodeSEI:             {Mnemonic: OpMnemonicSEI, AddressMode: AddrModeImplied, Cycles: 2, execute: executeSEI},
+	OpCodeADCAbsoluteY:    {Mnemonic: OpMnemonicADC, AddressMode: AddrModeAbsoluteY, Cycles: 4, PageCrossPenalty: true, execute: executeADC},
+	OpCodeUnNOPImplied3:   {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeNOP},
+	OpCodeUnRRAAbsoluteY:  {Mnemonic: OpMnemonicRRA, AddressMode: AddrModeAbsoluteY, Cycles: 7, Unofficial: true, execute: executeRRA},
+	OpCodeUnNOPAbsoluteX3: {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, Unofficial: true, execute: executeNOP},
+	OpCodeADCAbsoluteX:    {Mnemonic: OpMnemonicADC, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, execute: executeADC},
+	OpCodeRORAbsoluteX:    {Mnemonic: OpMnemonicROR, AddressMode: AddrModeAbsoluteX, Cycles: 7, execute: executeROR},
+	OpCodeUnRRAAbsoluteX:  {Mnemonic: OpMnemonicRRA, AddressMode: AddrModeAbsoluteX, Cycles: 7, Unofficial: true, execute: executeRRA},
+	OpCodeUnNOPImmediate:  {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeImmediate, Cycles: 2, Unofficial: true, execute: executeNOP},
+	OpCodeSTAIndirectX:    {Mnemonic: OpMnemonicSTA, AddressMode: AddrModeIndirectX, Cycles: 6, execute: executeSTA},
+	OpCodeUnNOPImmediate1: {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeImmediate, Cycles: 2, Unofficial: true, execute: executeNOP},
+	OpCodeUnSAXIndirectX:  {Mnemonic: OpMnemonicSAX, AddressMode: AddrModeIndirectX, Cycles: 6, Unofficial: true, execute: executeSAX},
+	OpCodeSTYZero:         {Mnemonic: OpMnemonicSTY, AddressMode: AddrModeZero, Cycles: 3, execute: executeSTY},
+	OpCodeSTAZero:         {Mnemonic: OpMnemonicSTA, AddressMode: AddrModeZero, Cycles: 3, execute: executeSTA},
+	OpCodeSTXZero:         {Mnemonic: OpMnemonicSTX, AddressMode: AddrModeZero, Cycles: 3, execute: executeSTX},
+	OpCodeUnSAXZero:       {Mnemonic: OpMnemonicSAX, AddressMode: AddrModeZero, Cycles: 3, Unofficial: true, execute: executeSAX},
+	OpCodeDEY:             {Mnemonic: OpMnemonicDEY, AddressMode: AddrModeImplied, Cycles: 2, execute: executeDEY},
+	OpCodeUnNOPImmediate2: {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeImmediate, Cycles: 2, Unofficial: true, execute: executeNOP},
+	OpCodeTXA:             {Mnemonic: OpMnemonicTXA, AddressMode: AddrModeImplied, Cycles: 2, execute: executeTXA},
+	OpCodeUnXAAImmediate:  {Mnemonic: OpMnemonicXAA, AddressMode: AddrModeImmediate, Cycles: 2, Unofficial: true, execute: executeXAA},
+	OpCodeSTYAbsolute:     {Mnemonic: OpMnemonicSTY, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeSTY},
+	OpCodeSTAAbsolute:     {Mnemonic: OpMnemonicSTA, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeSTA},
+	OpCodeSTXAbsolute:     {Mnemonic: OpMnemonicSTX, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeSTX},
+	OpCodeUnSAXAbsolute:   {Mnemonic: OpMnemonicSAX, AddressMode: AddrModeAbsolute, Cycles: 4, Unofficial: true, execute: executeSAX},
+	OpCodeBCC:             {Mnemonic: OpMnemonicBCC, AddressMode: AddrModeRelative, Cycles: 2, execute: executeBCC},
+	OpCodeSTAIndirectY:    {Mnemonic: OpMnemonicSTA, AddressMode: AddrModeIndirectY, Cycles: 6, execute: executeSTA},
+	OpCodeUnJAM8:          {Mnemonic: OpMnemonicJAM, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeJAM},
+	OpCodeUnSHAIndirectY:  {Mnemonic: OpMnemonicSHA, AddressMode: AddrModeIndirectY, Cycles: 6, Unofficial: true, execute: executeSHA},
+	OpCodeSTYZeroX:        {Mnemonic: OpMnemonicSTY, AddressMode: AddrModeZeroX, Cycles: 4, execute: executeSTY},
+	OpCodeSTAZeroX:        {Mnemonic: OpMnemonicSTA, AddressMode: AddrModeZeroX, Cycles: 4, execute: executeSTA},
+	OpCodeSTXZeroY:        {Mnemonic: OpMnemonicSTX, AddressMode: AddrModeZeroY, Cycles: 4, execute: executeSTX},
+	OpCodeUnSAXZeroY:      {Mnemonic: OpMnemonicSAX, AddressMode: AddrModeZeroY, Cycles: 4, Unofficial: true, execute: executeSAX},
+	OpCodeTYA:             {Mnemonic: OpMnemonicTYA, AddressMode: AddrModeImplied, Cycles: 2, execute: executeTYA},
+	OpCodeSTAAbsoluteY:    {Mnemonic: OpMnemonicSTA, AddressMode: AddrModeAbsoluteY, Cycles: 5, execute: executeSTA},
+	OpCodeTXS:             {Mnemonic: OpMnemonicTXS, AddressMode: AddrModeImplied, Cycles: 2, execute: executeTXS},
+	OpCodeUnTASAbsoluteY:  {Mnemonic: OpMnemonicTAS, AddressMode: AddrModeAbsoluteY, Cycles: 5, Unofficial: true, execute: executeTAS},
+	OpCodeUnSHYAbsoluteX:  {Mnemonic: OpMnemonicSHY, AddressMode: AddrModeAbsoluteX, Cycles: 5, Unofficial: true, execute: executeSHY},
+	OpCodeSTAAbsoluteX:    {Mnemonic: OpMnemonicSTA, AddressMode: AddrModeAbsoluteX, Cycles: 5, execute: executeSTA},
+	OpCodeUnSHXAbsoluteY:  {Mnemonic: OpMnemonicSHX, AddressMode: AddrModeAbsoluteY, Cycles: 5, Unofficial: true, execute: executeSHX},
+	OpCodeUnSHAAbsoluteY:  {Mnemonic: OpMnemonicSHA, AddressMode: AddrModeAbsoluteY, Cycles: 5, Unofficial: true, execute: executeSHA},
+	OpCodeLDYImmediate:    {Mnemonic: OpMnemonicLDY, AddressMode: AddrModeImmediate, Cycles: 2, execute: executeLDY},
+	OpCodeLDAIndirectX:    {Mnemonic: OpMnemonicLDA, AddressMode: AddrModeIndirectX, Cycles: 6, execute: executeLDA},
+	OpCodeLDXImmediate:    {Mnemonic: OpMnemonicLDX, AddressMode: AddrModeImmediate, Cycles: 2, execute: executeLDX},
+	OpCodeUnLAXIndirectX:  {Mnemonic: OpMnemonicLAX, AddressMode: AddrModeIndirectX, Cycles: 6, Unofficial: true, execute: executeLAX},
+	OpCodeLDYZero:         {Mnemonic: OpMnemonicLDY, AddressMode: AddrModeZero, Cycles: 3, execute: executeLDY},
+	OpCodeLDAZero:         {Mnemonic: OpMnemonicLDA, AddressMode: AddrModeZero, Cycles: 3, execute: executeLDA},
+	OpCodeLDXZero:         {Mnemonic: OpMnemonicLDX, AddressMode: AddrModeZero, Cycles: 3, execute: executeLDX},
+	OpCodeUnLAXZero:       {Mnemonic: OpMnemonicLAX, AddressMode: AddrModeZero, Cycles: 3, Unofficial: true, execute: executeLAX},
+	OpCodeTAY:             {Mnemonic: OpMnemonicTAY, AddressMode: AddrModeImplied, Cycles: 2, execute: executeTAY},
+	OpCodeLDAImmediate:    {Mnemonic: OpMnemonicLDA, AddressMode: AddrModeImmediate, Cycles: 2, execute: executeLDA},
+	OpCodeTAX:             {Mnemonic: OpMnemonicTAX, AddressMode: AddrModeImplied, Cycles: 2, execute: executeTAX},
+	OpCodeUnLAXImmediate:  {Mnemonic: OpMnemonicLAX, AddressMode: AddrModeImmediate, Cycles: 2, Unofficial: true, execute: executeLAX},
+	OpCodeLDYAbsolute:     {Mnemonic: OpMnemonicLDY, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeLDY},
+	OpCodeLDAAbsolute:     {Mnemonic: OpMnemonicLDA, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeLDA},
+	OpCodeLDXAbsolute:     {Mnemonic: OpMnemonicLDX, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeLDX},
+	OpCodeUnLAXAbsolute:   {Mnemonic: OpMnemonicLAX, AddressMode: AddrModeAbsolute, Cycles: 4, Unofficial: true, execute: executeLAX},
+	OpCodeBCS:             {Mnemonic: OpMnemonicBCS, AddressMode: AddrModeRelative, Cycles: 2, execute: executeBCS},
+	OpCodeLDAIndirectY:    {Mnemonic: OpMnemonicLDA, AddressMode: AddrModeIndirectY, Cycles: 5, PageCrossPenalty: true, execute: executeLDA},
+	OpCodeUnJAM9:          {Mnemonic: OpMnemonicJAM, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeJAM},
+	OpCodeUnLAXIndirectY:  {Mnemonic: OpMnemonicLAX, AddressMode: AddrModeIndirectY, Cycles: 5, PageCrossPenalty: true, Unofficial: true, execute: executeLAX},
+	OpCodeLDYZeroX:        {Mnemonic: OpMnemonicLDY, AddressMode: AddrModeZeroX, Cycles: 4, execute: executeLDY},
+	OpCodeLDAZeroX:        {Mnemonic: OpMnemonicLDA, AddressMode: AddrModeZeroX, Cycles: 4, execute: executeLDA},
+	OpCodeLDXZeroY:        {Mnemonic: OpMnemonicLDX, AddressMode: AddrModeZeroY, Cycles: 4, execute: executeLDX},
+	OpCodeUnLAXZeroY:      {Mnemonic: OpMnemonicLAX, AddressMode: AddrModeZeroY, Cycles: 4, Unofficial: true, execute: executeLAX},
+	OpCodeCLV:             {Mnemonic: OpMnemonicCLV, AddressMode: AddrModeImplied, Cycles: 2, execute: executeCLV},
+	OpCodeLDAAbsoluteY:    {Mnemonic: OpMnemonicLDA, AddressMode: AddrModeAbsoluteY, Cycles: 4, PageCrossPenalty: true, execute: executeLDA},
+	OpCodeTSX:             {Mnemonic: OpMnemonicTSX, AddressMode: AddrModeImplied, Cycles: 2, execute: executeTSX},
+	OpCodeUnLASAbsoluteY:  {Mnemonic: OpMnemonicLAS, AddressMode: AddrModeAbsoluteY, Cycles: 4, PageCrossPenalty: true, Unofficial: true, execute: executeLAS},
+	OpCodeLDYAbsoluteX:    {Mnemonic: OpMnemonicLDY, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, execute: executeLDY},
+	OpCodeLDAAbsoluteX:    {Mnemonic: OpMnemonicLDA, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, execute: executeLDA},
+	OpCodeLDXAbsoluteY:    {Mnemonic: OpMnemonicLDX, AddressMode: AddrModeAbsoluteY, Cycles: 4, PageCrossPenalty: true, execute: executeLDX},
+	OpCodeUnLAXAbsoluteY:  {Mnemonic: OpMnemonicLAX, AddressMode: AddrModeAbsoluteY, Cycles: 4, PageCrossPenalty: true, Unofficial: true, execute: executeLAX},
+	OpCodeCPYImmediate:    {Mnemonic: OpMnemonicCPY, AddressMode: AddrModeImmediate, Cycles: 2, execute: executeCPY},
+	OpCodeCMPIndirectX:    {Mnemonic: OpMnemonicCMP, AddressMode: AddrModeIndirectX, Cycles: 6, execute: executeCMP},
+	OpCodeUnNOPImmediate3: {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeImmediate, Cycles: 2, Unofficial: true, execute: executeNOP},
+	OpCodeUnDCPIndirectX:  {Mnemonic: OpMnemonicDCP, AddressMode: AddrModeIndirectX, Cycles: 8, Unofficial: true, execute: executeDCP},
+	OpCodeCPYZero:         {Mnemonic: OpMnemonicCPY, AddressMode: AddrModeZero, Cycles: 3, execute: executeCPY},
+	OpCodeCMPZero:         {Mnemonic: OpMnemonicCMP, AddressMode: AddrModeZero, Cycles: 3, execute: executeCMP},
+	OpCodeDECZero:         {Mnemonic: OpMnemonicDEC, AddressMode: AddrModeZero, Cycles: 5, execute: executeDEC},
+	OpCodeUnDCPZero:       {Mnemonic: OpMnemonicDCP, AddressMode: AddrModeZero, Cycles: 5, Unofficial: true, execute: executeDCP},
+	OpCodeINY:             {Mnemonic: OpMnemonicINY, AddressMode: AddrModeImplied, Cycles: 2, execute: executeINY},
+	OpCodeCMPImmediate:    {Mnemonic: OpMnemonicCMP, AddressMode: AddrModeImmediate, Cycles: 2, execute: executeCMP},
+	OpCodeDEX:             {Mnemonic: OpMnemonicDEX, AddressMode: AddrModeImplied, Cycles: 2, execute: executeDEX},
+	OpCodeUnAXSImmediate:  {Mnemonic: OpMnemonicAXS, AddressMode: AddrModeImmediate, Cycles: 2, Unofficial: true, execute: executeAXS},
+	OpCodeCPYAbsolute:     {Mnemonic: OpMnemonicCPY, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeCPY},
+	OpCodeCMPAbsolute:     {Mnemonic: OpMnemonicCMP, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeCMP},
+	OpCodeDECAbsolute:     {Mnemonic: OpMnemonicDEC, AddressMode: AddrModeAbsolute, Cycles: 6, execute: executeDEC},
+	OpCodeUnDCPAbsolute:   {Mnemonic: OpMnemonicDCP, AddressMode: AddrModeAbsolute, Cycles: 6, Unofficial: true, execute: executeDCP},
+	OpCodeBNE:             {Mnemonic: OpMnemonicBNE, AddressMode: AddrModeRelative, Cycles: 2, execute: executeBNE},
+	OpCodeCMPIndirectY:    {Mnemonic: OpMnemonicCMP, AddressMode: AddrModeIndirectY, Cycles: 5, PageCrossPenalty: true, execute: executeCMP},
+	OpCodeUnJAM10:         {Mnemonic: OpMnemonicJAM, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeJAM},
+	OpCodeUnDCPIndirectY:  {Mnemonic: OpMnemonicDCP, AddressMode: AddrModeIndirectY, Cycles: 8, Unofficial: true, execute: executeDCP},
+	OpCodeUnNOPZeroX4:     {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeZeroX, Cycles: 4, Unofficial: true, execute: executeNOP},
+	OpCodeCMPZeroX:        {Mnemonic: OpMnemonicCMP, AddressMode: AddrModeZeroX, Cycles: 4, execute: executeCMP},
+	OpCodeDECZeroX:        {Mnemonic: OpMnemonicDEC, AddressMode: AddrModeZeroX, Cycles: 6, execute: executeDEC},
+	OpCodeUnDCPZeroX:      {Mnemonic: OpMnemonicDCP, AddressMode: AddrModeZeroX, Cycles: 6, Unofficial: true, execute: executeDCP},
+	OpCodeCLD:             {Mnemonic: OpMnemonicCLD, AddressMode: AddrModeImplied, Cycles: 2, execute: executeCLD},
+	OpCodeCMPAbsoluteY:    {Mnemonic: OpMnemonicCMP, AddressMode: AddrModeAbsoluteY, Cycles: 4, PageCrossPenalty: true, execute: executeCMP},
+	OpCodeUnNOPImplied4:   {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeNOP},
+	OpCodeUnDCPAbsoluteY:  {Mnemonic: OpMnemonicDCP, AddressMode: AddrModeAbsoluteY, Cycles: 7, Unofficial: true, execute: executeDCP},
+	OpCodeUnNOPAbsoluteX4: {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, Unofficial: true, execute: executeNOP},
+	OpCodeCMPAbsoluteX:    {Mnemonic: OpMnemonicCMP, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, execute: executeCMP},
+	OpCodeDECAbsoluteX:    {Mnemonic: OpMnemonicDEC, AddressMode: AddrModeAbsoluteX, Cycles: 7, execute: executeDEC},
+	OpCodeUnDCPAbsoluteX:  {Mnemonic: OpMnemonicDCP, AddressMode: AddrModeAbsoluteX, Cycles: 7, Unofficial: true, execute: executeDCP},
+	OpCodeCPXImmediate:    {Mnemonic: OpMnemonicCPX, AddressMode: AddrModeImmediate, Cycles: 2, execute: executeCPX},
+	OpCodeSBCIndirectX:    {Mnemonic: OpMnemonicSBC, AddressMode: AddrModeIndirectX, Cycles: 6, execute: executeSBC},
+	OpCodeUnNOPImmediate4: {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeImmediate, Cycles: 2, Unofficial: true, execute: executeNOP},
+	OpCodeUnISBIndirectX:  {Mnemonic: OpMnemonicISB, AddressMode: AddrModeIndirectX, Cycles: 8, Unofficial: true, execute: executeISB},
+	OpCodeCPXZero:         {Mnemonic: OpMnemonicCPX, AddressMode: AddrModeZero, Cycles: 3, execute: executeCPX},
+	OpCodeSBCZero:         {Mnemonic: OpMnemonicSBC, AddressMode: AddrModeZero, Cycles: 3, execute: executeSBC},
+	OpCodeINCZero:         {Mnemonic: OpMnemonicINC, AddressMode: AddrModeZero, Cycles: 5, execute: executeINC},
+	OpCodeUnISBZero:       {Mnemonic: OpMnemonicISB, AddressMode: AddrModeZero, Cycles: 5, Unofficial: true, execute: executeISB},
+	OpCodeINX:             {Mnemonic: OpMnemonicINX, AddressMode: AddrModeImplied, Cycles: 2, execute: executeINX},
+	OpCodeSBCImmediate:    {Mnemonic: OpMnemonicSBC, AddressMode: AddrModeImmediate, Cycles: 2, execute: executeSBC},
+	OpCodeNOP:             {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeImplied, Cycles: 2, execute: executeNOP},
+	OpCodeUnSBCImmediate0: {Mnemonic: OpMnemonicSBC, AddressMode: AddrModeImmediate, Cycles: 2, Unofficial: true, execute: executeSBC},
+	OpCodeCPXAbsolute:     {Mnemonic: OpMnemonicCPX, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeCPX},
+	OpCodeSBCAbsolute:     {Mnemonic: OpMnemonicSBC, AddressMode: AddrModeAbsolute, Cycles: 4, execute: executeSBC},
+	OpCodeINCAbsolute:     {Mnemonic: OpMnemonicINC, AddressMode: AddrModeAbsolute, Cycles: 6, execute: executeINC},
+	OpCodeUnISBAbsolute:   {Mnemonic: OpMnemonicISB, AddressMode: AddrModeAbsolute, Cycles: 6, Unofficial: true, execute: executeISB},
+	OpCodeBEQ:             {Mnemonic: OpMnemonicBEQ, AddressMode: AddrModeRelative, Cycles: 2, execute: executeBEQ},
+	OpCodeSBCIndirectY:    {Mnemonic: OpMnemonicSBC, AddressMode: AddrModeIndirectY, Cycles: 5, PageCrossPenalty: true, execute: executeSBC},
+	OpCodeUnJAM11:         {Mnemonic: OpMnemonicJAM, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeJAM},
+	OpCodeUnISBIndirectY:  {Mnemonic: OpMnemonicISB, AddressMode: AddrModeIndirectY, Cycles: 8, Unofficial: true, execute: executeISB},
+	OpCodeUnNOPZeroX5:     {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeZeroX, Cycles: 4, Unofficial: true, execute: executeNOP},
+	OpCodeSBCZeroX:        {Mnemonic: OpMnemonicSBC, AddressMode: AddrModeZeroX, Cycles: 4, execute: executeSBC},
+	OpCodeINCZeroX:        {Mnemonic: OpMnemonicINC, AddressMode: AddrModeZeroX, Cycles: 6, execute: executeINC},
+	OpCodeUnISBZeroX:      {Mnemonic: OpMnemonicISB, AddressMode: AddrModeZeroX, Cycles: 6, Unofficial: true, execute: executeISB},
+	OpCodeSED:             {Mnemonic: OpMnemonicSED, AddressMode: AddrModeImplied, Cycles: 2, execute: executeSED},
+	OpCodeSBCAbsoluteY:    {Mnemonic: OpMnemonicSBC, AddressMode: AddrModeAbsoluteY, Cycles: 4, PageCrossPenalty: true, execute: executeSBC},
+	OpCodeUnNOPImplied5:   {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeImplied, Cycles: 2, Unofficial: true, execute: executeNOP},
+	OpCodeUnISBAbsoluteY:  {Mnemonic: OpMnemonicISB, AddressMode: AddrModeAbsoluteY, Cycles: 7, Unofficial: true, execute: executeISB},
+	OpCodeUnNOPAbsoluteX5: {Mnemonic: OpMnemonicNOP, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, Unofficial: true, execute: executeNOP},
+	OpCodeSBCAbsoluteX:    {Mnemonic: OpMnemonicSBC, AddressMode: AddrModeAbsoluteX, Cycles: 4, PageCrossPenalty: true, execute: executeSBC},
+	OpCodeINCAbsoluteX:    {Mnemonic: OpMnemonicINC, AddressMode: AddrModeAbsoluteX, Cycles: 7, execute: executeINC},
+	OpCodeUnISBAbsoluteX:  {Mnemonic: OpMnemonicISB, AddressMode: AddrModeAbsoluteX, Cycles: 7, Unofficial: true, execute: executeISB},
+}
+
+func init() {
+	for opCode := range OpCodes {
+		OpCodes[opCode].Access = memoryAccessOf(OpCodes[opCode].Mnemonic)
+	}
+}
+
+func memoryAccessOf(mnemonic string) MemoryAccess {
+	switch mnemonic {
+	case OpMnemonicJMP, OpMnemonicJSR:
+		return MemoryAccessNone
+	case OpMnemonicSTA, OpMnemonicSTX, OpMnemonicSTY, OpMnemonicSAX, OpMnemonicSHA, OpMnemonicSHX, OpMnemonicSHY, OpMnemonicTAS:
+		return MemoryAccessWrite
+	case OpMnemonicASL, OpMnemonicLSR, OpMnemonicROL, OpMnemonicROR, OpMnemonicINC, OpMnemonicDEC,
+		OpMnemonicSLO, OpMnemonicSRE, OpMnemonicRLA, OpMnemonicRRA, OpMnemonicDCP, OpMnemonicISB:
+		return MemoryAccessReadModifyWrite
+	default:
+		return MemoryAccessRead
+	}
+}
+
+// Instruction is an operation decoded from its op code and arguments.
+type Instruction struct {
+	baseOperation
+}
+
+// Decode builds the operation identified by opCode. arg0 and arg1 are the
+// bytes following the op code in memory; they're ignored if the operation
+// doesn't need them. If opCode isn't valid, an error is returned.
+func Decode(opCode uint8, arg0 uint8, arg1 uint8) (Instruction, error) {
+	info := &OpCodes[opCode]
+	if !info.IsValid() {
+		return Instruction{}, InvalidOpCodeError{
+			OpCode: opCode,
+		}
+	}
+
+	inst := Instruction{
+		baseOperation{
+			code:        opCode,
+			addressMode: info.AddressMode,
+			mnemonic:    info.Mnemonic,
+			unofficial:  info.Unofficial,
+		},
+	}
+
+	switch info.Size() {
+	case 3:
+		inst.args[1] = arg1
+		fallthrough
+	case 2:
+		inst.args[0] = arg0
+	}
+
+	return inst, nil
+}
+
+// LookupOpCode finds the op code of the operation with the given mnemonic
+// and address mode. Official op codes are preferred over unofficial ones.
+// As both are written the same way, AddrModeRelative also matches
+// AddrModeZero. If there's no such operation, an error is returned.
+func LookupOpCode(mnemonic string, addressMode uint8) (uint8, error) {
+	var mnemonicFound bool
+
+	for _, unofficial := range []bool{false, true} {
+		for opCode, info := range OpCodes {
+			if !info.IsValid() || info.Mnemonic != mnemonic {
+				continue
+			}
+
+			mnemonicFound = true
+
+			if info.Unofficial != unofficial {
+				continue
+			}
+
+			if info.AddressMode == addressMode ||
+				(addressMode == AddrModeRelative && info.AddressMode == AddrModeZero) {
+				return uint8(opCode), nil
+			}
+		}
+	}
+
+	if !mnemonicFound {
+		return 0, InvalidMnemonicError{
+			Mnemonic: mnemonic,
+		}
+	}
+
+	return 0, InvalidAddressModeError{
+		AddressMode: addressMode,
+	}
+}
@@ -0,0 +1,88 @@
+package cpu
+
+import "testing"
+
+func TestOpCodes_Count(t *testing.T) {
+	var official, unofficial int
+
+	for _, info := range OpCodes {
+		if !info.IsValid() {
+			continue
+		}
+
+		if info.Unofficial {
+			unofficial++
+		} else {
+			official++
+		}
+	}
+
+	if official != 151 {
+		t.Errorf("unexpected number of official op codes; got=%v, want=%v", official, 151)
+	}
+	if unofficial != 105 {
+		t.Errorf("unexpected number of unofficial op codes; got=%v, want=%v", unofficial, 105)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	inst, err := Decode(OpCodeADCAbsoluteX, 0x34, 0x12)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m := inst.Mnemonic(); m != OpMnemonicADC {
+		t.Errorf("unexpected mnemonic; got=%v, want=%v", m, OpMnemonicADC)
+	}
+	if mode := inst.AddressMode(); mode != AddrModeAbsoluteX {
+		t.Errorf("unexpected address mode; got=%v, want=%v", AddressModeString(mode), AddressModeString(AddrModeAbsoluteX))
+	}
+	if size := inst.Size(); size != 3 {
+		t.Errorf("unexpected size; got=%v, want=%v", size, 3)
+	}
+	if cycles := inst.Cycles(); cycles != 4 {
+		t.Errorf("unexpected cycles; got=%v, want=%v", cycles, 4)
+	}
+	if arg := inst.WordArg(); arg != 0x1234 {
+		t.Errorf("unexpected argument; got=%04X, want=%04X", arg, 0x1234)
+	}
+
+	// unused arguments are discarded
+	if inst, _ = Decode(OpCodeLDAImmediate, 0x56, 0x78); inst.WordArg() != 0x0056 {
+		t.Errorf("unexpected argument for a 2-byte operation; got=%04X, want=%04X", inst.WordArg(), 0x0056)
+	}
+}
+
+func TestLookupOpCode(t *testing.T) {
+	// every official op code must be found from its mnemonic and address mode
+	for code, info := range OpCodes {
+		if !info.IsValid() || info.Unofficial {
+			continue
+		}
+
+		opCode, err := LookupOpCode(info.Mnemonic, info.AddressMode)
+		if err != nil {
+			t.Errorf("failed to look up $%02X: %v", code, err)
+		} else if int(opCode) != code {
+			t.Errorf("unexpected op code for %v (%v); got=$%02X, want=$%02X", info.Mnemonic, AddressModeString(info.AddressMode), opCode, code)
+		}
+	}
+
+	if opCode, err := LookupOpCode(OpMnemonicLDA, AddrModeRelative); err != nil || opCode != OpCodeLDAZero {
+		t.Errorf("relative address mode should match zero page; got=$%02X (%v), want=$%02X", opCode, err, OpCodeLDAZero)
+	}
+
+	if _, err := LookupOpCode("XYZ", AddrModeImplied); err == nil {
+		t.Error("looking up an invalid mnemonic should fail")
+	}
+
+	if _, err := LookupOpCode(OpMnemonicLDA, AddrModeImplied); err == nil {
+		t.Error("looking up an invalid address mode should fail")
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		_, _ = Decode(OpCodeADCAbsoluteX, 0x34, 0x12)
+	}
+}
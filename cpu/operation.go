@@ -30,6 +30,26 @@ func AddressModeString(addressMode uint8) string {
 		return "immediate"
 	case AddrModeImplied:
 		return "implied"
+	case AddrModeRelative:
+		return "relative"
+	case AddrModeAbsolute:
+		return "absolute"
+	case AddrModeZero:
+		return "zero page"
+	case AddrModeIndirect:
+		return "indirect"
+	case AddrModeAbsoluteX:
+		return "absolute,X"
+	case AddrModeAbsoluteY:
+		return "absolute,Y"
+	case AddrModeZeroX:
+		return "zero page,X"
+	case AddrModeZeroY:
+		return "zero page,Y"
+	case AddrModeIndirectX:
+		return "(indirect,X)"
+	case AddrModeIndirectY:
+		return "(indirect),Y"
 	default:
 		return fmt.Sprintf("[address mode = %v]", addressMode)
 	}
@@ -39,44 +59,43 @@ type baseOperation struct {
 	code        uint8
 	addressMode uint8
 	mnemonic    string
-	args        []uint8
+	args        [2]uint8
 	unofficial  bool
 }
 
-func (op baseOperation) AddressMode() uint8 {
+func breakWordIntoArgs(word uint16) [2]uint8 {
+	return [2]uint8{uint8(word), uint8(word >> 8)}
+}
+
+func (op *baseOperation) AddressMode() uint8 {
 	return op.addressMode
 }
 
-func (op baseOperation) ByteArg() uint8 {
+func (op *baseOperation) ByteArg() uint8 {
 	return op.args[0]
 }
 
-func (op baseOperation) Code() uint8 {
+func (op *baseOperation) Code() uint8 {
 	return op.code
 }
 
-func (op baseOperation) Mnemonic() string {
+func (op *baseOperation) Cycles() uint8 {
+	return OpCodes[op.code].Cycles
+}
+
+func (op *baseOperation) Mnemonic() string {
 	return op.mnemonic
 }
 
-func (op baseOperation) IsUnofficial() bool {
+func (op *baseOperation) IsUnofficial() bool {
 	return op.unofficial
 }
 
-func (op baseOperation) Size() uint8 {
-	switch op.AddressMode() {
-	case AddrModeAccumulator, AddrModeImplied:
-		return 1
-	case AddrModeImmediate, AddrModeRelative, AddrModeZero, AddrModeZeroX, AddrModeZeroY, AddrModeIndirectX, AddrModeIndirectY:
-		return 2
-	case AddrModeAbsolute, AddrModeIndirect, AddrModeAbsoluteX, AddrModeAbsoluteY:
-		return 3
-	default:
-		return 1
-	}
+func (op *baseOperation) Size() uint8 {
+	return addressModeSize(op.AddressMode())
 }
 
-func (op baseOperation) StringWithEnv(env OperationEnvironment) string {
+func (op *baseOperation) StringWithEnv(env OperationEnvironment) string {
 	var mnemonic string
 
 	if op.IsUnofficial() {
@@ -157,16 +176,23 @@ func (op baseOperation) StringWithEnv(env OperationEnvironment) string {
 	}
 }
 
-func (op baseOperation) String() string {
+func (op *baseOperation) String() string {
 	return op.StringWithEnv(nil)
 }
 
-func (op baseOperation) WordArg() uint16 {
-	return util.JoinBytesInWord(op.args)
+func (op *baseOperation) WordArg() uint16 {
+	return util.JoinBytesInWord(op.args[:])
 }
 
-func (op baseOperation) ExecuteIn(env OperationEnvironment) (uint8, error) {
-	return 0, nil
+func (op *baseOperation) ExecuteIn(env OperationEnvironment) (uint8, error) {
+	execute := OpCodes[op.code].execute
+	if execute == nil {
+		return 0, InvalidOpCodeError{
+			OpCode: op.code,
+		}
+	}
+
+	return execute(op, env)
 }
 
 type Operation interface {
@@ -174,6 +200,7 @@ type Operation interface {
 	AddressMode() uint8
 	Mnemonic() string
 	Size() uint8
+	Cycles() uint8
 	ByteArg() uint8
 	WordArg() uint16
 	StringWithEnv(OperationEnvironment) string
@@ -217,6 +244,7 @@ type OperationEnvironment interface {
 	IncrementProgramCounter(uint8)
 	GetStatus() uint8
 	SetStatus(uint8)
+	GetVariant() Variant
 
 	PushByteToStack(uint8)
 	PushWordToStack(uint16)
@@ -224,4 +252,6 @@ type OperationEnvironment interface {
 	PullWordFromStack() uint16
 
 	FetchOperand(Operation) (uint16, uint8, bool)
+
+	Jam(Operation) error
 }
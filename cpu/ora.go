@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicORA = "ORA"
 
@@ -47,7 +39,7 @@ func NewORAIndirectX(indirectAddress uint8) *ORA {
 			code:        OpCodeORAIndirectX,
 			addressMode: AddrModeIndirectX,
 			mnemonic:    OpMnemonicORA,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -59,7 +51,7 @@ func NewORAZero(zeroAddress uint8) *ORA {
 			code:        OpCodeORAZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicORA,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -71,7 +63,7 @@ func NewORAImmediate(value uint8) *ORA {
 			code:        OpCodeORAImmediate,
 			addressMode: AddrModeImmediate,
 			mnemonic:    OpMnemonicORA,
-			args:        []uint8{value},
+			args:        [2]uint8{value},
 		},
 	}
 }
@@ -83,7 +75,7 @@ func NewORAAbsolute(absoluteAddress uint16) *ORA {
 			code:        OpCodeORAAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicORA,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -95,7 +87,7 @@ func NewORAIndirectY(indirectAddress uint8) *ORA {
 			code:        OpCodeORAIndirectY,
 			addressMode: AddrModeIndirectY,
 			mnemonic:    OpMnemonicORA,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -107,7 +99,7 @@ func NewORAZeroX(zeroAddress uint8) *ORA {
 			code:        OpCodeORAZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicORA,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -119,7 +111,7 @@ func NewORAAbsoluteY(absoluteAddress uint16) *ORA {
 			code:        OpCodeORAAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicORA,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -131,134 +123,12 @@ func NewORAAbsoluteX(absoluteAddress uint16) *ORA {
 			code:        OpCodeORAAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicORA,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewORABinary(opCode uint8, data io.Reader) (*ORA, error) {
-	switch opCode {
-	case OpCodeORAIndirectX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewORAIndirectX(addr), nil
-	case OpCodeORAZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewORAZero(addr), nil
-	case OpCodeORAImmediate:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewORAImmediate(addr), nil
-	case OpCodeORAAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewORAAbsolute(addr), nil
-	case OpCodeORAIndirectY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewORAIndirectY(addr), nil
-	case OpCodeORAZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewORAZeroX(addr), nil
-	case OpCodeORAAbsoluteY:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewORAAbsoluteY(addr), nil
-	case OpCodeORAAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewORAAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewORAFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*ORA, error) {
-	switch addrMode {
-	case AddrModeIndirectX:
-		return NewORAIndirectX(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewORAZero(arg0), nil
-	case AddrModeImmediate:
-		return NewORAImmediate(arg0), nil
-	case AddrModeAbsolute:
-		return NewORAAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeIndirectY:
-		return NewORAIndirectY(arg0), nil
-	case AddrModeZeroX:
-		return NewORAZeroX(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewORAAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeAbsoluteX:
-		return NewORAAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op ORA) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeIndirectX:
-		return 6
-	case AddrModeZero:
-		return 3
-	case AddrModeImmediate:
-		return 2
-	case AddrModeAbsolute:
-		return 4
-	case AddrModeIndirectY:
-		return 5
-	case AddrModeZeroX:
-		return 4
-	case AddrModeAbsoluteY:
-		return 4
-	case AddrModeAbsoluteX:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op ORA) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeORA(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	_, operand, pageCrossed := env.FetchOperand(op)
 
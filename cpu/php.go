@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicPHP = "PHP"
 
@@ -34,50 +29,10 @@ func NewPHP() *PHP {
 	}
 }
 
-func NewPHPBinary(opCode uint8, data io.Reader) (*PHP, error) {
-	switch opCode {
-	case OpCodePHP:
-		return NewPHP(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewPHPFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*PHP, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewPHP(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op PHP) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 3
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op PHP) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executePHP(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
-	// TODO: put this login in GetStatus? but what about /IRQ and /NMI?
-	hasBreak := env.IsStatusBreak()
-	if !hasBreak {
-		env.SetStatusBreak(true)
-	}
-	env.PushByteToStack(env.GetStatus())
-	if !hasBreak {
-		env.SetStatusBreak(false)
-	}
+	pushStatus(env, true)
 
 	env.IncrementProgramCounter(op.Size())
 
@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicPLA = "PLA"
 
@@ -34,39 +29,7 @@ func NewPLA() *PLA {
 	}
 }
 
-func NewPLABinary(opCode uint8, data io.Reader) (*PLA, error) {
-	switch opCode {
-	case OpCodePLA:
-		return NewPLA(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewPLAFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*PLA, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewPLA(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op PLA) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op PLA) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executePLA(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	newA := env.PullByteFromStack()
@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicRLA = "RLA"
 
@@ -45,7 +37,7 @@ func NewUnRLAIndirectX(indirectAddress uint8) *RLA {
 			code:        OpCodeUnRLAIndirectX,
 			addressMode: AddrModeIndirectX,
 			mnemonic:    OpMnemonicRLA,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 			unofficial:  true,
 		},
 	}
@@ -57,7 +49,7 @@ func NewUnRLAZero(zeroAddress uint8) *RLA {
 			code:        OpCodeUnRLAZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicRLA,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -69,7 +61,7 @@ func NewUnRLAAbsolute(absoluteAddress uint16) *RLA {
 			code:        OpCodeUnRLAAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicRLA,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -81,7 +73,7 @@ func NewUnRLAIndirectY(indirectAddress uint8) *RLA {
 			code:        OpCodeUnRLAIndirectY,
 			addressMode: AddrModeIndirectY,
 			mnemonic:    OpMnemonicRLA,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 			unofficial:  true,
 		},
 	}
@@ -93,7 +85,7 @@ func NewUnRLAZeroX(zeroAddress uint8) *RLA {
 			code:        OpCodeUnRLAZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicRLA,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -105,7 +97,7 @@ func NewUnRLAAbsoluteY(absoluteAddress uint16) *RLA {
 			code:        OpCodeUnRLAAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicRLA,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -117,121 +109,13 @@ func NewUnRLAAbsoluteX(absoluteAddress uint16) *RLA {
 			code:        OpCodeUnRLAAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicRLA,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
 }
 
-func NewRLABinary(opCode uint8, data io.Reader) (*RLA, error) {
-	switch opCode {
-	case OpCodeUnRLAIndirectX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnRLAIndirectX(addr), nil
-	case OpCodeUnRLAZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnRLAZero(addr), nil
-	case OpCodeUnRLAAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnRLAAbsolute(addr), nil
-	case OpCodeUnRLAIndirectY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnRLAIndirectY(addr), nil
-	case OpCodeUnRLAZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnRLAZeroX(addr), nil
-	case OpCodeUnRLAAbsoluteY:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnRLAAbsoluteY(addr), nil
-	case OpCodeUnRLAAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnRLAAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{opCode}
-	}
-}
-
-func NewRLAFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*RLA, error) {
-	switch addrMode {
-	case AddrModeIndirectX:
-		return NewUnRLAIndirectX(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewUnRLAZero(arg0), nil
-	case AddrModeAbsolute:
-		return NewUnRLAAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeIndirectY:
-		return NewUnRLAIndirectY(arg0), nil
-	case AddrModeZeroX:
-		return NewUnRLAZeroX(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewUnRLAAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeAbsoluteX:
-		return NewUnRLAAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op RLA) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeIndirectX:
-		return 8
-	case AddrModeZero:
-		return 5
-	case AddrModeAbsolute:
-		return 6
-	case AddrModeIndirectY:
-		return 8
-	case AddrModeZeroX:
-		return 6
-	case AddrModeAbsoluteY:
-		return 7
-	case AddrModeAbsoluteX:
-		return 7
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op RLA) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeRLA(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	address, operand, _ := env.FetchOperand(op)
 
@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicROL = "ROL"
 
@@ -41,7 +33,7 @@ func NewROLZero(zeroAddress uint8) *ROL {
 			code:        OpCodeROLZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicROL,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -64,7 +56,7 @@ func NewROLAbsolute(absoluteAddress uint16) *ROL {
 			code:        OpCodeROLAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicROL,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -76,7 +68,7 @@ func NewROLZeroX(zeroAddress uint8) *ROL {
 			code:        OpCodeROLZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicROL,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -88,92 +80,12 @@ func NewROLAbsoluteX(absoluteAddress uint16) *ROL {
 			code:        OpCodeROLAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicROL,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewROLBinary(opCode uint8, data io.Reader) (*ROL, error) {
-	switch opCode {
-	case OpCodeROLZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewROLZero(addr), nil
-	case OpCodeROLAccumulator:
-		return NewROLAccumulator(), nil
-	case OpCodeROLAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewROLAbsolute(addr), nil
-	case OpCodeROLZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewROLZeroX(addr), nil
-	case OpCodeROLAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewROLAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewROLFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*ROL, error) {
-	switch addrMode {
-	case AddrModeZero, AddrModeRelative:
-		return NewROLZero(arg0), nil
-	case AddrModeAccumulator:
-		return NewROLAccumulator(), nil
-	case AddrModeAbsolute:
-		return NewROLAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeZeroX:
-		return NewROLZeroX(arg0), nil
-	case AddrModeAbsoluteX:
-		return NewROLAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op ROL) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeZero:
-		return 5
-	case AddrModeAccumulator:
-		return 2
-	case AddrModeAbsolute:
-		return 6
-	case AddrModeZeroX:
-		return 6
-	case AddrModeAbsoluteX:
-		return 7
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op ROL) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeROL(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	address, operand, _ := env.FetchOperand(op)
 
@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicROR = "ROR"
 
@@ -41,7 +33,7 @@ func NewRORZero(zeroAddress uint8) *ROR {
 			code:        OpCodeRORZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicROR,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -64,7 +56,7 @@ func NewRORAbsolute(absoluteAddress uint16) *ROR {
 			code:        OpCodeRORAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicROR,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -76,7 +68,7 @@ func NewRORZeroX(zeroAddress uint8) *ROR {
 			code:        OpCodeRORZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicROR,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -88,92 +80,12 @@ func NewRORAbsoluteX(absoluteAddress uint16) *ROR {
 			code:        OpCodeRORAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicROR,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
 
-func NewRORBinary(opCode uint8, data io.Reader) (*ROR, error) {
-	switch opCode {
-	case OpCodeRORZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewRORZero(addr), nil
-	case OpCodeRORAccumulator:
-		return NewRORAccumulator(), nil
-	case OpCodeRORAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewRORAbsolute(addr), nil
-	case OpCodeRORZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewRORZeroX(addr), nil
-	case OpCodeRORAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewRORAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewRORFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*ROR, error) {
-	switch addrMode {
-	case AddrModeZero, AddrModeRelative:
-		return NewRORZero(arg0), nil
-	case AddrModeAccumulator:
-		return NewRORAccumulator(), nil
-	case AddrModeAbsolute:
-		return NewRORAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeZeroX:
-		return NewRORZeroX(arg0), nil
-	case AddrModeAbsoluteX:
-		return NewRORAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op ROR) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeZero:
-		return 5
-	case AddrModeAccumulator:
-		return 2
-	case AddrModeAbsolute:
-		return 6
-	case AddrModeZeroX:
-		return 6
-	case AddrModeAbsoluteX:
-		return 7
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op ROR) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeROR(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	address, operand, _ := env.FetchOperand(op)
 
@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicRTS = "RTS"
 
@@ -34,39 +29,7 @@ func NewRTS() *RTS {
 	}
 }
 
-func NewRTSBinary(opCode uint8, data io.Reader) (*RTS, error) {
-	switch opCode {
-	case OpCodeRTS:
-		return NewRTS(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewRTSFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*RTS, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewRTS(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op RTS) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 6
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op RTS) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeRTS(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	env.SetProgramCounter(env.PullWordFromStack() + 1)
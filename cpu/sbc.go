@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicSBC = "SBC"
 
@@ -51,7 +43,7 @@ func NewSBCIndirectX(indirectAddress uint8) *SBC {
 			code:        OpCodeSBCIndirectX,
 			addressMode: AddrModeIndirectX,
 			mnemonic:    OpMnemonicSBC,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -63,7 +55,7 @@ func NewSBCZero(zeroAddress uint8) *SBC {
 			code:        OpCodeSBCZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicSBC,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -75,7 +67,7 @@ func NewSBCImmediate(value uint8) *SBC {
 			code:        OpCodeSBCImmediate,
 			addressMode: AddrModeImmediate,
 			mnemonic:    OpMnemonicSBC,
-			args:        []uint8{value},
+			args:        [2]uint8{value},
 		},
 	}
 }
@@ -87,7 +79,7 @@ func NewSBCAbsolute(absoluteAddress uint16) *SBC {
 			code:        OpCodeSBCAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicSBC,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -99,7 +91,7 @@ func NewSBCIndirectY(indirectAddress uint8) *SBC {
 			code:        OpCodeSBCIndirectY,
 			addressMode: AddrModeIndirectY,
 			mnemonic:    OpMnemonicSBC,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 		},
 	}
 }
@@ -111,7 +103,7 @@ func NewSBCZeroX(zeroAddress uint8) *SBC {
 			code:        OpCodeSBCZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicSBC,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -123,7 +115,7 @@ func NewSBCAbsoluteY(absoluteAddress uint16) *SBC {
 			code:        OpCodeSBCAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicSBC,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -135,7 +127,7 @@ func NewSBCAbsoluteX(absoluteAddress uint16) *SBC {
 			code:        OpCodeSBCAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicSBC,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -147,165 +139,82 @@ func NewUnSBCImmediate0(value uint8) *SBC {
 			code:        OpCodeUnSBCImmediate0,
 			addressMode: AddrModeImmediate,
 			mnemonic:    OpMnemonicSBC,
-			args:        []uint8{value},
+			args:        [2]uint8{value},
 			unofficial:  true,
 		},
 	}
 }
 
-func NewSBCBinary(opCode uint8, data io.Reader) (*SBC, error) {
-	switch opCode {
-	case OpCodeSBCIndirectX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewSBCIndirectX(addr), nil
-	case OpCodeSBCZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewSBCZero(addr), nil
-	case OpCodeSBCImmediate:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
+func executeSBC(op Operation, env OperationEnvironment) (uint8, error) {
+	cycles := op.Cycles()
+	_, operand, pageCrossed := env.FetchOperand(op)
 
-		return NewSBCImmediate(addr), nil
-	case OpCodeSBCAbsolute:
-		var addr uint16
+	if pageCrossed {
+		cycles++
+	}
 
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
+	cycles += subtractWithBorrow(env, operand)
 
-		return NewSBCAbsolute(addr), nil
-	case OpCodeSBCIndirectY:
-		var addr uint8
+	env.IncrementProgramCounter(op.Size())
 
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
+	return cycles, nil
+}
 
-		return NewSBCIndirectY(addr), nil
-	case OpCodeSBCZeroX:
-		var addr uint8
+// subtractWithBorrow subtracts value and the borrow (the opposite of the
+// carry) from the accumulator, setting the flags like SBC does. The number of
+// extra cycles taken is returned.
+func subtractWithBorrow(env OperationEnvironment, value uint8) uint8 {
+	oldA := env.GetAccumulator()
 
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
+	var borrow int16
+	if !env.IsStatusCarry() {
+		borrow = 1
+	}
 
-		return NewSBCZeroX(addr), nil
-	case OpCodeSBCAbsoluteY:
-		var addr uint16
+	result := int16(oldA) - int16(value) - borrow
+	signedResult := int16(int8(oldA)) - int16(int8(value)) - borrow
 
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
+	// the flags always come from the binary result, except for the N and Z
+	// flags on the 65C02 in decimal mode
+	env.SetStatusCarry(result >= 0)
+	env.SetStatusZero(uint8(result) == 0x00)
+	env.SetStatusOverflow(signedResult > 127 || signedResult < -128)
+	env.SetStatusNegative(uint8(result)&0x80 != 0x00)
 
-		return NewSBCAbsoluteY(addr), nil
-	case OpCodeSBCAbsoluteX:
-		var addr uint16
+	if !isDecimalModeActive(env) {
+		env.SetAccumulator(uint8(result))
 
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
+		return 0
+	}
 
-		return NewSBCAbsoluteX(addr), nil
-	case OpCodeUnSBCImmediate0:
-		var addr uint8
+	low := int16(oldA&0x0F) - int16(value&0x0F) - borrow
 
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
+	if env.GetVariant() == Variant65C02 {
+		if result < 0 {
+			result -= 0x60
 		}
-
-		return NewUnSBCImmediate0(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
+		if low < 0 {
+			result -= 0x06
 		}
-	}
-}
 
-func NewSBCFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*SBC, error) {
-	switch addrMode {
-	case AddrModeIndirectX:
-		return NewSBCIndirectX(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewSBCZero(arg0), nil
-	case AddrModeImmediate:
-		return NewSBCImmediate(arg0), nil
-	case AddrModeAbsolute:
-		return NewSBCAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeIndirectY:
-		return NewSBCIndirectY(arg0), nil
-	case AddrModeZeroX:
-		return NewSBCZeroX(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewSBCAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeAbsoluteX:
-		return NewSBCAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
+		env.SetAccumulator(uint8(result))
+		env.SetStatusZero(uint8(result) == 0x00)
+		env.SetStatusNegative(uint8(result)&0x80 != 0x00)
 
-func (op SBC) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeIndirectX:
-		return 6
-	case AddrModeZero:
-		return 3
-	case AddrModeImmediate:
-		return 2
-	case AddrModeAbsolute:
-		return 4
-	case AddrModeIndirectY:
-		return 5
-	case AddrModeZeroX:
-		return 4
-	case AddrModeAbsoluteY:
-		return 4
-	case AddrModeAbsoluteX:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
+		return 1
 	}
-}
-
-func (op SBC) ExecuteIn(env OperationEnvironment) (uint8, error) {
-	cycles := op.Cycles()
-	_, operand, pageCrossed := env.FetchOperand(op)
 
-	if pageCrossed {
-		cycles++
+	// each nibble is a decimal digit, adjusted separately
+	if low < 0 {
+		low = ((low - 0x06) & 0x0F) - 0x10
 	}
 
-	oldA := env.GetAccumulator()
-	result := oldA - operand
-	signedResult := int16(int8(oldA)) - int16(int8(operand))
-	if !env.IsStatusCarry() {
-		result--
-		signedResult--
+	result = int16(oldA&0xF0) - int16(value&0xF0) + low
+	if result < 0 {
+		result -= 0x60
 	}
 
-	env.SetAccumulator(result)
-	env.SetStatusCarry(result <= oldA)
-	env.SetStatusZero(result == 0x00)
-	env.SetStatusOverflow(signedResult > 127 || signedResult < -128)
-	env.SetStatusNegative(result&0x80 != 0x00)
-
-	env.IncrementProgramCounter(op.Size())
+	env.SetAccumulator(uint8(result))
 
-	return cycles, nil
+	return 0
 }
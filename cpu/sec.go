@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicSEC = "SEC"
 
@@ -34,39 +29,7 @@ func NewSEC() *SEC {
 	}
 }
 
-func NewSECBinary(opCode uint8, data io.Reader) (*SEC, error) {
-	switch opCode {
-	case OpCodeSEC:
-		return NewSEC(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewSECFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*SEC, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewSEC(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op SEC) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op SEC) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeSEC(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	env.SetStatusCarry(true)
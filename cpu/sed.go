@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicSED = "SED"
 
@@ -34,39 +29,7 @@ func NewSED() *SED {
 	}
 }
 
-func NewSEDBinary(opCode uint8, data io.Reader) (*SED, error) {
-	switch opCode {
-	case OpCodeSED:
-		return NewSED(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewSEDFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*SED, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewSED(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op SED) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op SED) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeSED(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	env.SetStatusDecimal(true)
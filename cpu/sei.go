@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicSEI = "SEI"
 
@@ -34,39 +29,7 @@ func NewSEI() *SEI {
 	}
 }
 
-func NewSEIBinary(opCode uint8, data io.Reader) (*SEI, error) {
-	switch opCode {
-	case OpCodeSEI:
-		return NewSEI(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewSEIFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*SEI, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewSEI(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op SEI) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op SEI) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeSEI(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	env.SetStatusInterrupt(true)
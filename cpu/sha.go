@@ -0,0 +1,74 @@
+package cpu
+
+const (
+	OpMnemonicSHA = "SHA"
+
+	// unofficial opcodes
+	OpCodeUnSHAIndirectY = 0x93
+	OpCodeUnSHAAbsoluteY = 0x9F
+)
+
+func IsOpCodeValidSHA(opCode uint8) bool {
+	return opCode == OpCodeUnSHAIndirectY ||
+		opCode == OpCodeUnSHAAbsoluteY
+}
+
+func IsMnemonicValidSHA(mnemonic string) bool {
+	return mnemonic == OpMnemonicSHA
+}
+
+type SHA struct {
+	baseOperation
+}
+
+// 0x93: SHA ($NN), Y
+func NewUnSHAIndirectY(indirectAddress uint8) *SHA {
+	return &SHA{
+		baseOperation{
+			code:        OpCodeUnSHAIndirectY,
+			addressMode: AddrModeIndirectY,
+			mnemonic:    OpMnemonicSHA,
+			args:        [2]uint8{indirectAddress},
+			unofficial:  true,
+		},
+	}
+}
+
+// 0x9F: SHA $NNNN, Y
+func NewUnSHAAbsoluteY(absoluteAddress uint16) *SHA {
+	return &SHA{
+		baseOperation{
+			code:        OpCodeUnSHAAbsoluteY,
+			addressMode: AddrModeAbsoluteY,
+			mnemonic:    OpMnemonicSHA,
+			args:        breakWordIntoArgs(absoluteAddress),
+			unofficial:  true,
+		},
+	}
+}
+
+func executeSHA(op Operation, env OperationEnvironment) (uint8, error) {
+	cycles := op.Cycles()
+
+	storeWithHighByte(env, op, env.GetIndexY(), env.GetAccumulator()&env.GetIndexX())
+
+	env.IncrementProgramCounter(op.Size())
+
+	return cycles, nil
+}
+
+// storeWithHighByte implements the unstable stores (SHA, SHX, SHY, TAS):
+// value is ANDed with the high byte of the base address plus 1 before being
+// written to the indexed address. When indexing crosses a page, the high byte
+// of the indexed address is also replaced by the value written.
+func storeWithHighByte(env OperationEnvironment, op Operation, index uint8, value uint8) {
+	address, _, pageCrossed := env.FetchOperand(op)
+	baseAddress := address - uint16(index)
+
+	value &= uint8(baseAddress>>8) + 1
+	if pageCrossed {
+		address = uint16(value)<<8 | address&0x00FF
+	}
+
+	env.WriteByte(address, value)
+}
@@ -0,0 +1,43 @@
+package cpu
+
+const (
+	OpMnemonicSHX = "SHX"
+
+	// unofficial opcodes
+	OpCodeUnSHXAbsoluteY = 0x9E
+)
+
+func IsOpCodeValidSHX(opCode uint8) bool {
+	return opCode == OpCodeUnSHXAbsoluteY
+}
+
+func IsMnemonicValidSHX(mnemonic string) bool {
+	return mnemonic == OpMnemonicSHX
+}
+
+type SHX struct {
+	baseOperation
+}
+
+// 0x9E: SHX $NNNN, Y
+func NewUnSHXAbsoluteY(absoluteAddress uint16) *SHX {
+	return &SHX{
+		baseOperation{
+			code:        OpCodeUnSHXAbsoluteY,
+			addressMode: AddrModeAbsoluteY,
+			mnemonic:    OpMnemonicSHX,
+			args:        breakWordIntoArgs(absoluteAddress),
+			unofficial:  true,
+		},
+	}
+}
+
+func executeSHX(op Operation, env OperationEnvironment) (uint8, error) {
+	cycles := op.Cycles()
+
+	storeWithHighByte(env, op, env.GetIndexY(), env.GetIndexX())
+
+	env.IncrementProgramCounter(op.Size())
+
+	return cycles, nil
+}
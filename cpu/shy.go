@@ -0,0 +1,43 @@
+package cpu
+
+const (
+	OpMnemonicSHY = "SHY"
+
+	// unofficial opcodes
+	OpCodeUnSHYAbsoluteX = 0x9C
+)
+
+func IsOpCodeValidSHY(opCode uint8) bool {
+	return opCode == OpCodeUnSHYAbsoluteX
+}
+
+func IsMnemonicValidSHY(mnemonic string) bool {
+	return mnemonic == OpMnemonicSHY
+}
+
+type SHY struct {
+	baseOperation
+}
+
+// 0x9C: SHY $NNNN, X
+func NewUnSHYAbsoluteX(absoluteAddress uint16) *SHY {
+	return &SHY{
+		baseOperation{
+			code:        OpCodeUnSHYAbsoluteX,
+			addressMode: AddrModeAbsoluteX,
+			mnemonic:    OpMnemonicSHY,
+			args:        breakWordIntoArgs(absoluteAddress),
+			unofficial:  true,
+		},
+	}
+}
+
+func executeSHY(op Operation, env OperationEnvironment) (uint8, error) {
+	cycles := op.Cycles()
+
+	storeWithHighByte(env, op, env.GetIndexX(), env.GetIndexY())
+
+	env.IncrementProgramCounter(op.Size())
+
+	return cycles, nil
+}
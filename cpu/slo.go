@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicSLO = "SLO"
 
@@ -46,7 +38,7 @@ func NewUnSLOIndirectX(indirectAddress uint8) *SLO {
 			code:        OpCodeUnSLOIndirectX,
 			addressMode: AddrModeIndirectX,
 			mnemonic:    OpMnemonicSLO,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 			unofficial:  true,
 		},
 	}
@@ -59,7 +51,7 @@ func NewUnSLOZero(zeroAddress uint8) *SLO {
 			code:        OpCodeUnSLOZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicSLO,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -72,7 +64,7 @@ func NewUnSLOAbsolute(absoluteAddress uint16) *SLO {
 			code:        OpCodeUnSLOAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicSLO,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -85,7 +77,7 @@ func NewUnSLOIndirectY(indirectAddress uint8) *SLO {
 			code:        OpCodeUnSLOIndirectY,
 			addressMode: AddrModeIndirectY,
 			mnemonic:    OpMnemonicSLO,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 			unofficial:  true,
 		},
 	}
@@ -98,7 +90,7 @@ func NewUnSLOZeroX(zeroAddress uint8) *SLO {
 			code:        OpCodeUnSLOZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicSLO,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -111,7 +103,7 @@ func NewUnSLOAbsoluteY(absoluteAddress uint16) *SLO {
 			code:        OpCodeUnSLOAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicSLO,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -124,123 +116,13 @@ func NewUnSLOAbsoluteX(absoluteAddress uint16) *SLO {
 			code:        OpCodeUnSLOAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicSLO,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
 }
 
-func NewSLOBinary(opCode uint8, data io.Reader) (*SLO, error) {
-	switch opCode {
-	case OpCodeUnSLOIndirectX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSLOIndirectX(addr), nil
-	case OpCodeUnSLOZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSLOZero(addr), nil
-	case OpCodeUnSLOAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSLOAbsolute(addr), nil
-	case OpCodeUnSLOIndirectY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSLOIndirectY(addr), nil
-	case OpCodeUnSLOZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSLOZeroX(addr), nil
-	case OpCodeUnSLOAbsoluteY:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSLOAbsoluteY(addr), nil
-	case OpCodeUnSLOAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSLOAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewSLOFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*SLO, error) {
-	switch addrMode {
-	case AddrModeIndirectX:
-		return NewUnSLOIndirectX(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewUnSLOZero(arg0), nil
-	case AddrModeAbsolute:
-		return NewUnSLOAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeIndirectY:
-		return NewUnSLOIndirectY(arg0), nil
-	case AddrModeZeroX:
-		return NewUnSLOZeroX(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewUnSLOAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeAbsoluteX:
-		return NewUnSLOAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op SLO) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeIndirectX:
-		return 8
-	case AddrModeZero:
-		return 5
-	case AddrModeAbsolute:
-		return 6
-	case AddrModeIndirectY:
-		return 8
-	case AddrModeZeroX:
-		return 6
-	case AddrModeAbsoluteY:
-		return 7
-	case AddrModeAbsoluteX:
-		return 7
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op SLO) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeSLO(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	address, operand, _ := env.FetchOperand(op)
 
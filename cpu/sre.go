@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicSRE = "SRE"
 
@@ -46,7 +38,7 @@ func NewUnSREIndirectX(indirectAddress uint8) *SRE {
 			code:        OpCodeUnSREIndirectX,
 			addressMode: AddrModeIndirectX,
 			mnemonic:    OpMnemonicSRE,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 			unofficial:  true,
 		},
 	}
@@ -59,7 +51,7 @@ func NewUnSREZero(zeroAddress uint8) *SRE {
 			code:        OpCodeUnSREZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicSRE,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -72,7 +64,7 @@ func NewUnSREAbsolute(absoluteAddress uint16) *SRE {
 			code:        OpCodeUnSREAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicSRE,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -85,7 +77,7 @@ func NewUnSREIndirectY(indirectAddress uint8) *SRE {
 			code:        OpCodeUnSREIndirectY,
 			addressMode: AddrModeIndirectY,
 			mnemonic:    OpMnemonicSRE,
-			args:        []uint8{indirectAddress},
+			args:        [2]uint8{indirectAddress},
 			unofficial:  true,
 		},
 	}
@@ -98,7 +90,7 @@ func NewUnSREZeroX(zeroAddress uint8) *SRE {
 			code:        OpCodeUnSREZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicSRE,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 			unofficial:  true,
 		},
 	}
@@ -111,7 +103,7 @@ func NewUnSREAbsoluteY(absoluteAddress uint16) *SRE {
 			code:        OpCodeUnSREAbsoluteY,
 			addressMode: AddrModeAbsoluteY,
 			mnemonic:    OpMnemonicSRE,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
@@ -124,123 +116,13 @@ func NewUnSREAbsoluteX(absoluteAddress uint16) *SRE {
 			code:        OpCodeUnSREAbsoluteX,
 			addressMode: AddrModeAbsoluteX,
 			mnemonic:    OpMnemonicSRE,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 			unofficial:  true,
 		},
 	}
 }
 
-func NewSREBinary(opCode uint8, data io.Reader) (*SRE, error) {
-	switch opCode {
-	case OpCodeUnSREIndirectX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSREIndirectX(addr), nil
-	case OpCodeUnSREZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSREZero(addr), nil
-	case OpCodeUnSREAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSREAbsolute(addr), nil
-	case OpCodeUnSREIndirectY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSREIndirectY(addr), nil
-	case OpCodeUnSREZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSREZeroX(addr), nil
-	case OpCodeUnSREAbsoluteY:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSREAbsoluteY(addr), nil
-	case OpCodeUnSREAbsoluteX:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewUnSREAbsoluteX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewSREFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*SRE, error) {
-	switch addrMode {
-	case AddrModeIndirectX:
-		return NewUnSREIndirectX(arg0), nil
-	case AddrModeZero, AddrModeRelative:
-		return NewUnSREZero(arg0), nil
-	case AddrModeAbsolute:
-		return NewUnSREAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeIndirectY:
-		return NewUnSREIndirectY(arg0), nil
-	case AddrModeZeroX:
-		return NewUnSREZeroX(arg0), nil
-	case AddrModeAbsoluteY:
-		return NewUnSREAbsoluteY(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeAbsoluteX:
-		return NewUnSREAbsoluteX(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op SRE) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeIndirectX:
-		return 8
-	case AddrModeZero:
-		return 5
-	case AddrModeAbsolute:
-		return 6
-	case AddrModeIndirectY:
-		return 8
-	case AddrModeZeroX:
-		return 6
-	case AddrModeAbsoluteY:
-		return 7
-	case AddrModeAbsoluteX:
-		return 7
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op SRE) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeSRE(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	address, operand, _ := env.FetchOperand(op)
 
@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicSTX = "STX"
 
@@ -37,7 +29,7 @@ func NewSTXZero(zeroAddress uint8) *STX {
 			code:        OpCodeSTXZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicSTX,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -49,7 +41,7 @@ func NewSTXAbsolute(absoluteAddress uint16) *STX {
 			code:        OpCodeSTXAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicSTX,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -61,74 +53,12 @@ func NewSTXZeroY(zeroAddress uint8) *STX {
 			code:        OpCodeSTXZeroY,
 			addressMode: AddrModeZeroY,
 			mnemonic:    OpMnemonicSTX,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
 
-func NewSTXBinary(opCode uint8, data io.Reader) (*STX, error) {
-	switch opCode {
-	case OpCodeSTXZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewSTXZero(addr), nil
-	case OpCodeSTXAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewSTXAbsolute(addr), nil
-	case OpCodeSTXZeroY:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewSTXZeroY(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewSTXFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*STX, error) {
-	switch addrMode {
-	case AddrModeZero, AddrModeRelative:
-		return NewSTXZero(arg0), nil
-	case AddrModeAbsolute:
-		return NewSTXAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeZeroY:
-		return NewSTXZeroY(arg0), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op STX) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeZero:
-		return 3
-	case AddrModeAbsolute:
-		return 4
-	case AddrModeZeroY:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op STX) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeSTX(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	address, _, _ := env.FetchOperand(op)
 
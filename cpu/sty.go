@@ -1,13 +1,5 @@
 package cpu
 
-import (
-	"encoding/binary"
-	"io"
-	"log"
-
-	"github.com/cd1/nes-emulator/util"
-)
-
 const (
 	OpMnemonicSTY = "STY"
 
@@ -37,7 +29,7 @@ func NewSTYZero(zeroAddress uint8) *STY {
 			code:        OpCodeSTYZero,
 			addressMode: AddrModeZero,
 			mnemonic:    OpMnemonicSTY,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
@@ -49,7 +41,7 @@ func NewSTYAbsolute(absoluteAddress uint16) *STY {
 			code:        OpCodeSTYAbsolute,
 			addressMode: AddrModeAbsolute,
 			mnemonic:    OpMnemonicSTY,
-			args:        util.BreakWordIntoBytes(absoluteAddress),
+			args:        breakWordIntoArgs(absoluteAddress),
 		},
 	}
 }
@@ -61,74 +53,12 @@ func NewSTYZeroX(zeroAddress uint8) *STY {
 			code:        OpCodeSTYZeroX,
 			addressMode: AddrModeZeroX,
 			mnemonic:    OpMnemonicSTY,
-			args:        []uint8{zeroAddress},
+			args:        [2]uint8{zeroAddress},
 		},
 	}
 }
 
-func NewSTYBinary(opCode uint8, data io.Reader) (*STY, error) {
-	switch opCode {
-	case OpCodeSTYZero:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewSTYZero(addr), nil
-	case OpCodeSTYAbsolute:
-		var addr uint16
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewSTYAbsolute(addr), nil
-	case OpCodeSTYZeroX:
-		var addr uint8
-
-		if err := binary.Read(data, binary.LittleEndian, &addr); err != nil {
-			return nil, err
-		}
-
-		return NewSTYZeroX(addr), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewSTYFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*STY, error) {
-	switch addrMode {
-	case AddrModeZero, AddrModeRelative:
-		return NewSTYZero(arg0), nil
-	case AddrModeAbsolute:
-		return NewSTYAbsolute(util.JoinBytesInWord([]uint8{arg0, arg1})), nil
-	case AddrModeZeroX:
-		return NewSTYZeroX(arg0), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op STY) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeZero:
-		return 3
-	case AddrModeAbsolute:
-		return 4
-	case AddrModeZeroX:
-		return 4
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op STY) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeSTY(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 	address, _, _ := env.FetchOperand(op)
 
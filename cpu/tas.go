@@ -0,0 +1,46 @@
+package cpu
+
+const (
+	OpMnemonicTAS = "TAS"
+
+	// unofficial opcodes
+	OpCodeUnTASAbsoluteY = 0x9B
+)
+
+func IsOpCodeValidTAS(opCode uint8) bool {
+	return opCode == OpCodeUnTASAbsoluteY
+}
+
+func IsMnemonicValidTAS(mnemonic string) bool {
+	return mnemonic == OpMnemonicTAS
+}
+
+type TAS struct {
+	baseOperation
+}
+
+// 0x9B: TAS $NNNN, Y
+func NewUnTASAbsoluteY(absoluteAddress uint16) *TAS {
+	return &TAS{
+		baseOperation{
+			code:        OpCodeUnTASAbsoluteY,
+			addressMode: AddrModeAbsoluteY,
+			mnemonic:    OpMnemonicTAS,
+			args:        breakWordIntoArgs(absoluteAddress),
+			unofficial:  true,
+		},
+	}
+}
+
+func executeTAS(op Operation, env OperationEnvironment) (uint8, error) {
+	cycles := op.Cycles()
+
+	value := env.GetAccumulator() & env.GetIndexX()
+	env.SetStackPointer(value)
+
+	storeWithHighByte(env, op, env.GetIndexY(), value)
+
+	env.IncrementProgramCounter(op.Size())
+
+	return cycles, nil
+}
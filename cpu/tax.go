@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicTAX = "TAX"
 
@@ -34,39 +29,7 @@ func NewTAX() *TAX {
 	}
 }
 
-func NewTAXBinary(opCode uint8, data io.Reader) (*TAX, error) {
-	switch opCode {
-	case OpCodeTAX:
-		return NewTAX(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewTAXFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*TAX, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewTAX(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op TAX) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op TAX) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeTAX(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	a := env.GetAccumulator()
@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicTAY = "TAY"
 
@@ -34,39 +29,7 @@ func NewTAY() *TAY {
 	}
 }
 
-func NewTAYBinary(opCode uint8, data io.Reader) (*TAY, error) {
-	switch opCode {
-	case OpCodeTAY:
-		return NewTAY(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewTAYFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*TAY, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewTAY(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op TAY) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op TAY) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeTAY(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	a := env.GetAccumulator()
@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicTSX = "TSX"
 
@@ -34,39 +29,7 @@ func NewTSX() *TSX {
 	}
 }
 
-func NewTSXBinary(opCode uint8, data io.Reader) (*TSX, error) {
-	switch opCode {
-	case OpCodeTSX:
-		return NewTSX(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewTSXFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*TSX, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewTSX(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op TSX) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op TSX) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeTSX(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	sp := env.GetStackPointer()
@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicTXA = "TXA"
 
@@ -34,39 +29,7 @@ func NewTXA() *TXA {
 	}
 }
 
-func NewTXABinary(opCode uint8, data io.Reader) (*TXA, error) {
-	switch opCode {
-	case OpCodeTXA:
-		return NewTXA(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewTXAFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*TXA, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewTXA(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op TXA) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op TXA) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeTXA(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	x := env.GetIndexX()
@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicTXS = "TXS"
 
@@ -34,39 +29,7 @@ func NewTXS() *TXS {
 	}
 }
 
-func NewTXSBinary(opCode uint8, data io.Reader) (*TXS, error) {
-	switch opCode {
-	case OpCodeTXS:
-		return NewTXS(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewTXSFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*TXS, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewTXS(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op TXS) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op TXS) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeTXS(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	env.SetStackPointer(env.GetIndexX())
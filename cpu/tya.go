@@ -1,10 +1,5 @@
 package cpu
 
-import (
-	"io"
-	"log"
-)
-
 const (
 	OpMnemonicTYA = "TYA"
 
@@ -34,39 +29,7 @@ func NewTYA() *TYA {
 	}
 }
 
-func NewTYABinary(opCode uint8, data io.Reader) (*TYA, error) {
-	switch opCode {
-	case OpCodeTYA:
-		return NewTYA(), nil
-	default:
-		return nil, InvalidOpCodeError{
-			OpCode: opCode,
-		}
-	}
-}
-
-func NewTYAFromBytes(addrMode uint8, arg0 uint8, arg1 uint8) (*TYA, error) {
-	switch addrMode {
-	case AddrModeImplied:
-		return NewTYA(), nil
-	default:
-		return nil, InvalidAddressModeError{
-			AddressMode: addrMode,
-		}
-	}
-}
-
-func (op TYA) Cycles() uint8 {
-	switch op.AddressMode() {
-	case AddrModeImplied:
-		return 2
-	default:
-		log.Printf("cannot calculate number of cycles for %#v: invalid address mode (%v)", op, op.AddressMode())
-		return 0
-	}
-}
-
-func (op TYA) ExecuteIn(env OperationEnvironment) (uint8, error) {
+func executeTYA(op Operation, env OperationEnvironment) (uint8, error) {
 	cycles := op.Cycles()
 
 	y := env.GetIndexY()
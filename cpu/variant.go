@@ -0,0 +1,36 @@
+package cpu
+
+// Variant identifies which flavour of the 6502 is emulated.
+type Variant uint8
+
+const (
+	// Variant2A03 is the CPU used by the NES: a NMOS 6502 without decimal mode.
+	Variant2A03 Variant = iota
+	// VariantNMOS6502 is the original 6502, with decimal mode.
+	VariantNMOS6502
+	// Variant65C02 has a decimal mode which sets the N and Z flags from the
+	// decimal result and takes one extra cycle.
+	Variant65C02
+)
+
+// HasDecimalMode checks if ADC and SBC honour the decimal flag.
+func (v Variant) HasDecimalMode() bool {
+	return v == VariantNMOS6502 || v == Variant65C02
+}
+
+func (v Variant) String() string {
+	switch v {
+	case Variant2A03:
+		return "2A03"
+	case VariantNMOS6502:
+		return "NMOS 6502"
+	case Variant65C02:
+		return "65C02"
+	default:
+		return "[unknown variant]"
+	}
+}
+
+func isDecimalModeActive(env OperationEnvironment) bool {
+	return env.IsStatusDecimal() && env.GetVariant().HasDecimalMode()
+}
@@ -0,0 +1,52 @@
+package cpu
+
+const (
+	OpMnemonicXAA = "XAA"
+
+	// unofficial opcodes
+	OpCodeUnXAAImmediate = 0x8B
+)
+
+// XAA and LAX #$NN depend on analog effects inside the CPU; the accumulator
+// is ORed with this value, which varies between chips, before being used.
+const unstableMagicConstant = 0xEE
+
+func IsOpCodeValidXAA(opCode uint8) bool {
+	return opCode == OpCodeUnXAAImmediate
+}
+
+func IsMnemonicValidXAA(mnemonic string) bool {
+	return mnemonic == OpMnemonicXAA
+}
+
+type XAA struct {
+	baseOperation
+}
+
+// 0x8B: XAA #$NN
+func NewUnXAAImmediate(value uint8) *XAA {
+	return &XAA{
+		baseOperation{
+			code:        OpCodeUnXAAImmediate,
+			addressMode: AddrModeImmediate,
+			mnemonic:    OpMnemonicXAA,
+			args:        [2]uint8{value},
+			unofficial:  true,
+		},
+	}
+}
+
+func executeXAA(op Operation, env OperationEnvironment) (uint8, error) {
+	cycles := op.Cycles()
+	_, operand, _ := env.FetchOperand(op)
+
+	result := (env.GetAccumulator() | unstableMagicConstant) & env.GetIndexX() & operand
+	env.SetAccumulator(result)
+
+	env.SetStatusZero(result == 0x00)
+	env.SetStatusNegative(result&0x80 != 0x00)
+
+	env.IncrementProgramCounter(op.Size())
+
+	return cycles, nil
+}
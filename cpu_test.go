@@ -0,0 +1,267 @@
+package nes
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/cd1/nes-emulator/cpu"
+)
+
+const testProgramAddress = 0x0600
+
+// runTestOperation executes a single operation, stored in RAM, after setup
+// has prepared the system.
+func runTestOperation(t *testing.T, setup func(*NES), program ...uint8) *NES {
+	system := &NES{
+		Bus: newFlatTestBus(),
+	}
+
+	system.CPU.StackPointer = 0xFD
+	system.CPU.ProgramCounter = testProgramAddress
+	system.SetStatus(0x00)
+
+	for i, b := range program {
+		system.WriteByte(testProgramAddress+uint16(i), b)
+	}
+
+	if setup != nil {
+		setup(system)
+	}
+
+	if _, err := system.Step(); err != nil {
+		t.Fatal(err)
+	}
+
+	return system
+}
+
+func TestCPU_UnofficialOpCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []uint8
+		setup   func(*NES)
+		check   func(*NES) bool
+	}{
+		{"ANC", []uint8{0x0B, 0xF0}, func(s *NES) { s.CPU.Accumulator = 0x8F }, func(s *NES) bool {
+			return s.CPU.Accumulator == 0x80 && s.IsStatusCarry() && s.IsStatusNegative()
+		}},
+		{"ALR", []uint8{0x4B, 0x03}, func(s *NES) { s.CPU.Accumulator = 0x81 }, func(s *NES) bool {
+			return s.CPU.Accumulator == 0x00 && s.IsStatusCarry() && s.IsStatusZero()
+		}},
+		{"ARR", []uint8{0x6B, 0xFF}, func(s *NES) { s.CPU.Accumulator = 0xC0; s.SetStatusCarry(true) }, func(s *NES) bool {
+			// 0xC0 >> 1 | 0x80 = 0xE0: bit 6 set -> carry, bit 6 == bit 5 -> no overflow
+			return s.CPU.Accumulator == 0xE0 && s.IsStatusCarry() && !s.IsStatusOverflow() && s.IsStatusNegative()
+		}},
+		{"ARR overflow", []uint8{0x6B, 0xFF}, func(s *NES) { s.CPU.Accumulator = 0x80 }, func(s *NES) bool {
+			return s.CPU.Accumulator == 0x40 && s.IsStatusCarry() && s.IsStatusOverflow()
+		}},
+		{"AXS", []uint8{0xCB, 0x01}, func(s *NES) { s.CPU.Accumulator = 0x0F; s.CPU.IndexX = 0x03 }, func(s *NES) bool {
+			return s.CPU.IndexX == 0x02 && s.IsStatusCarry() && !s.IsStatusZero()
+		}},
+		{"AXS borrow", []uint8{0xCB, 0x05}, func(s *NES) { s.CPU.Accumulator = 0x0F; s.CPU.IndexX = 0x03 }, func(s *NES) bool {
+			return s.CPU.IndexX == 0xFE && !s.IsStatusCarry() && s.IsStatusNegative()
+		}},
+		{"LAS", []uint8{0xBB, 0x00, 0x02}, func(s *NES) { s.CPU.IndexY = 0x10; s.WriteByte(0x0210, 0x3C) }, func(s *NES) bool {
+			return s.CPU.Accumulator == 0x3C&0xFD && s.CPU.IndexX == 0x3C&0xFD && s.CPU.StackPointer == 0x3C&0xFD
+		}},
+		{"SHA", []uint8{0x9F, 0x00, 0x02}, func(s *NES) { s.CPU.Accumulator = 0xFF; s.CPU.IndexX = 0x07; s.CPU.IndexY = 0x10 }, func(s *NES) bool {
+			return s.ReadByte(0x0210) == 0x07&0x03
+		}},
+		{"SHX page crossing", []uint8{0x9E, 0xF0, 0x02}, func(s *NES) { s.CPU.IndexX = 0x05; s.CPU.IndexY = 0x20 }, func(s *NES) bool {
+			// value = X & (0x02 + 1) = 0x01, which also replaces the high byte of 0x0310
+			return s.ReadByte(0x0110) == 0x01 && s.ReadByte(0x0310) == 0x00
+		}},
+		{"SHY", []uint8{0x9C, 0x00, 0x02}, func(s *NES) { s.CPU.IndexX = 0x01; s.CPU.IndexY = 0xFF }, func(s *NES) bool {
+			return s.ReadByte(0x0201) == 0x03
+		}},
+		{"TAS", []uint8{0x9B, 0x00, 0x02}, func(s *NES) { s.CPU.Accumulator = 0xF3; s.CPU.IndexX = 0x3F }, func(s *NES) bool {
+			return s.CPU.StackPointer == 0x33 && s.ReadByte(0x0200) == 0x03
+		}},
+		{"XAA", []uint8{0x8B, 0xFF}, func(s *NES) { s.CPU.Accumulator = 0x00; s.CPU.IndexX = 0x0F }, func(s *NES) bool {
+			return s.CPU.Accumulator == 0x0E
+		}},
+		{"LAX immediate", []uint8{0xAB, 0x35}, func(s *NES) { s.CPU.Accumulator = 0x11 }, func(s *NES) bool {
+			return s.CPU.Accumulator == 0x35&0xFF && s.CPU.IndexX == 0x35
+		}},
+		{"NOP immediate", []uint8{0x89, 0x12}, nil, func(s *NES) bool {
+			return s.CPU.ProgramCounter == testProgramAddress+2
+		}},
+		{"JAM", []uint8{0x02}, nil, func(s *NES) bool {
+			return s.CPU.ProgramCounter == testProgramAddress && s.IsJammed()
+		}},
+	}
+
+	for _, test := range tests {
+		system := runTestOperation(t, test.setup, test.program...)
+
+		if !test.check(system) {
+			t.Errorf("unexpected state after %v; A:%02X X:%02X Y:%02X P:%02X SP:%02X PC:%04X",
+				test.name, system.CPU.Accumulator, system.CPU.IndexX, system.CPU.IndexY, system.CPU.Status, system.CPU.StackPointer, system.CPU.ProgramCounter)
+		}
+	}
+}
+
+func TestCPU_Jam(t *testing.T) {
+	var logged bytes.Buffer
+
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	system := runTestOperation(t, nil, 0x02, 0xEA)
+
+	if !system.IsJammed() {
+		t.Fatal("CPU should be jammed")
+	}
+	if !bytes.Contains(logged.Bytes(), []byte("jammed")) {
+		t.Errorf("the jam should be logged; got=%q", logged.String())
+	}
+
+	cycles := system.Cycles()
+
+	// the CPU doesn't fetch anything else, but the time keeps passing
+	if _, err := system.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if pc := system.CPU.ProgramCounter; pc != testProgramAddress {
+		t.Errorf("unexpected PC while jammed; got=%04X, want=%04X", pc, testProgramAddress)
+	}
+	if system.Cycles() <= cycles {
+		t.Error("cycles should keep passing while jammed")
+	}
+
+	system.Reset()
+
+	if system.IsJammed() {
+		t.Error("CPU should not be jammed after reset")
+	}
+}
+
+func TestCPU_JamPolicy(t *testing.T) {
+	system := &NES{
+		Bus:       newFlatTestBus(),
+		JamPolicy: JamPolicyError,
+	}
+	system.CPU.ProgramCounter = testProgramAddress
+	system.WriteByte(testProgramAddress, 0x12)
+
+	_, err := system.Step()
+	if jamErr, ok := err.(cpu.CPUJammedError); !ok {
+		t.Errorf("unexpected error with the error policy; got=%v, want=%T", err, cpu.CPUJammedError{})
+	} else if jamErr.OpCode != 0x12 || jamErr.ProgramCounter != testProgramAddress {
+		t.Errorf("unexpected jam error; got=%+v", jamErr)
+	}
+
+	system = runTestOperation(t, func(s *NES) { s.JamPolicy = JamPolicyNOP }, 0x12)
+
+	if system.IsJammed() {
+		t.Error("CPU should not be jammed with the NOP policy")
+	}
+	if pc := system.CPU.ProgramCounter; pc != testProgramAddress+1 {
+		t.Errorf("unexpected PC with the NOP policy; got=%04X, want=%04X", pc, testProgramAddress+1)
+	}
+}
+
+func toBCD(value int) uint8 {
+	return uint8(value/10<<4 | value%10)
+}
+
+// executeDecimal runs ADC/SBC #value in decimal mode and returns the number of
+// cycles taken.
+func executeDecimal(t *testing.T, system *NES, opCode uint8, a uint8, value uint8, carry bool) uint16 {
+	system.CPU.ProgramCounter = testProgramAddress
+	system.CPU.Accumulator = a
+	system.SetStatus(StatusDecimal)
+	system.SetStatusCarry(carry)
+	system.WriteByte(testProgramAddress, opCode)
+	system.WriteByte(testProgramAddress+1, value)
+
+	cycles, err := system.Step()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return cycles
+}
+
+func TestCPU_DecimalMode(t *testing.T) {
+	for _, variant := range []cpu.Variant{cpu.VariantNMOS6502, cpu.Variant65C02} {
+		system := &NES{
+			Bus:        newFlatTestBus(),
+			CPUVariant: variant,
+		}
+
+		wantCycles := uint16(2)
+		if variant == cpu.Variant65C02 {
+			wantCycles++
+		}
+
+		for a := 0; a < 100; a++ {
+			for b := 0; b < 100; b++ {
+				for carry := 0; carry < 2; carry++ {
+					sum := a + b + carry
+					cycles := executeDecimal(t, system, cpu.OpCodeADCImmediate, toBCD(a), toBCD(b), carry == 1)
+
+					if got, want := system.CPU.Accumulator, toBCD(sum%100); got != want || system.IsStatusCarry() != (sum >= 100) || cycles != wantCycles {
+						t.Fatalf("%v: unexpected result for %02X + %02X + %v; got=%02X (C=%v, %v cycles), want=%02X (C=%v, %v cycles)",
+							variant, toBCD(a), toBCD(b), carry, got, system.IsStatusCarry(), cycles, want, sum >= 100, wantCycles)
+					}
+
+					difference := a - b - (1 - carry)
+					cycles = executeDecimal(t, system, cpu.OpCodeSBCImmediate, toBCD(a), toBCD(b), carry == 1)
+
+					if got, want := system.CPU.Accumulator, toBCD((difference+100)%100); got != want || system.IsStatusCarry() != (difference >= 0) || cycles != wantCycles {
+						t.Fatalf("%v: unexpected result for %02X - %02X - %v; got=%02X (C=%v, %v cycles), want=%02X (C=%v, %v cycles)",
+							variant, toBCD(a), toBCD(b), 1-carry, got, system.IsStatusCarry(), cycles, want, difference >= 0, wantCycles)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCPU_DecimalModeFlags(t *testing.T) {
+	tests := []struct {
+		variant      cpu.Variant
+		opCode       uint8
+		a, value     uint8
+		carry        bool
+		wantA        uint8
+		wantC, wantZ bool
+		wantN, wantV bool
+	}{
+		// the NMOS 6502 sets Z from the binary result ($9A) and N before the adjustment
+		{cpu.VariantNMOS6502, cpu.OpCodeADCImmediate, 0x99, 0x01, false, 0x00, true, false, true, false},
+		{cpu.Variant65C02, cpu.OpCodeADCImmediate, 0x99, 0x01, false, 0x00, true, true, false, false},
+		{cpu.VariantNMOS6502, cpu.OpCodeADCImmediate, 0x79, 0x00, true, 0x80, false, false, true, true},
+		{cpu.Variant65C02, cpu.OpCodeADCImmediate, 0x79, 0x00, true, 0x80, false, false, true, true},
+		// the NMOS 6502 sets every flag of SBC from the binary result ($FF)
+		{cpu.VariantNMOS6502, cpu.OpCodeSBCImmediate, 0x00, 0x01, true, 0x99, false, false, true, false},
+		{cpu.Variant65C02, cpu.OpCodeSBCImmediate, 0x00, 0x01, true, 0x99, false, false, true, false},
+		{cpu.VariantNMOS6502, cpu.OpCodeSBCImmediate, 0x21, 0x21, true, 0x00, true, true, false, false},
+		// the 2A03 ignores the decimal flag
+		{cpu.Variant2A03, cpu.OpCodeADCImmediate, 0x09, 0x01, false, 0x0A, false, false, false, false},
+		{cpu.Variant2A03, cpu.OpCodeSBCImmediate, 0x10, 0x01, true, 0x0F, true, false, false, false},
+	}
+
+	for _, test := range tests {
+		system := &NES{
+			Bus:        newFlatTestBus(),
+			CPUVariant: test.variant,
+		}
+
+		executeDecimal(t, system, test.opCode, test.a, test.value, test.carry)
+
+		if system.CPU.Accumulator != test.wantA ||
+			system.IsStatusCarry() != test.wantC ||
+			system.IsStatusZero() != test.wantZ ||
+			system.IsStatusNegative() != test.wantN ||
+			system.IsStatusOverflow() != test.wantV {
+			t.Errorf("%v: unexpected result for $%02X %02X, %02X (C=%v); got=%02X (C=%v Z=%v N=%v V=%v), want=%02X (C=%v Z=%v N=%v V=%v)",
+				test.variant, test.opCode, test.a, test.value, test.carry,
+				system.CPU.Accumulator, system.IsStatusCarry(), system.IsStatusZero(), system.IsStatusNegative(), system.IsStatusOverflow(),
+				test.wantA, test.wantC, test.wantZ, test.wantN, test.wantV)
+		}
+	}
+}
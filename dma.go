@@ -0,0 +1,43 @@
+package nes
+
+import "github.com/cd1/nes-emulator/ppu"
+
+const (
+	// the CPU is halted for 1 cycle before the DMA starts, plus 1 when it
+	// starts in an odd cycle, so the reads are aligned
+	OAMDMAHaltCycles = 1
+	// each byte takes a read and a write cycle
+	OAMDMACycles = 2 * ppu.OAMSize
+)
+
+// startOAMDMA makes the CPU copy page ($XX00-$XXFF) to the PPU OAM after
+// the current instruction.
+func (nes *NES) startOAMDMA(page uint8) {
+	nes.dmaPage = page
+	nes.dmaPending = true
+}
+
+// oamDMA copies the pending page to the PPU OAM, through OAMDATA, while the
+// CPU is halted. The number of cycles taken (513 or 514) is returned.
+func (nes *NES) oamDMA() uint16 {
+	nes.dmaPending = false
+
+	halt := uint16(OAMDMAHaltCycles)
+	if nes.cycles%2 == 1 {
+		halt++
+	}
+
+	// the halted CPU keeps reading the next op code
+	for i := uint16(0); i < halt; i++ {
+		nes.dummyRead(nes.CPU.ProgramCounter)
+	}
+
+	address := uint16(nes.dmaPage) << 8
+
+	for i := 0; i < ppu.OAMSize; i++ {
+		value := nes.ReadByte(address + uint16(i))
+		nes.WriteByte(PPURegistersStart+ppu.OAMDataRegister, value)
+	}
+
+	return halt + OAMDMACycles
+}
@@ -0,0 +1,72 @@
+package nes
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNES_OAMDMA(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []uint8
+		// cycles taken by the DMA
+		want uint16
+	}{
+		// LDA #$02; STA $4014: the DMA starts in the cycle 13
+		{"odd cycle", []uint8{0xA9, 0x02, 0x8D, 0x14, 0x40}, 514},
+		// LDA $00; STA $4014: the DMA starts in the cycle 14
+		{"even cycle", []uint8{0xA5, 0x00, 0x8D, 0x14, 0x40}, 513},
+	}
+
+	for _, test := range tests {
+		for _, cycleAccurate := range []bool{false, true} {
+			t.Run(fmt.Sprintf("%v/CycleAccurate=%v", test.name, cycleAccurate), func(t *testing.T) {
+				game := newTestGame(t, 1, 1)
+				copy(game.PRG, test.program)
+				// reset vector
+				game.PRG[0x3FFC] = 0x00
+				game.PRG[0x3FFD] = 0x80
+
+				system := NES{CycleAccurate: cycleAccurate}
+				if _, err := system.PowerOn(*game); err != nil {
+					t.Fatal(err)
+				}
+
+				system.WriteByte(0x0000, 0x02)
+				for i := 0; i < 0x100; i++ {
+					system.WriteByte(0x0200+uint16(i), uint8(i))
+				}
+
+				// the copy starts at OAMADDR
+				system.WriteByte(PPURegistersStart+0x03, 0x10)
+
+				if _, err := system.Step(); err != nil {
+					t.Fatal(err)
+				}
+
+				start := system.Cycles()
+
+				cycles, err := system.Step()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if want := 4 + test.want; cycles != want {
+					t.Errorf("unexpected cycles taken by STA with the DMA; got=%v, want=%v", cycles, want)
+				}
+				if got := system.Cycles() - start; got != uint64(cycles) {
+					t.Errorf("the DMA cycles should pass; got=%v, want=%v", got, cycles)
+				}
+				if cycleAccurate && system.stepAccesses != cycles {
+					t.Errorf("every DMA cycle should access the bus; got=%v, want=%v", system.stepAccesses, cycles)
+				}
+
+				for i := range system.PPU.OAM {
+					if want := uint8(i - 0x10); system.PPU.OAM[i] != want {
+						t.Fatalf("unexpected OAM byte %02X after the DMA; got=%02X, want=%02X", i, system.PPU.OAM[i], want)
+					}
+				}
+			})
+		}
+	}
+}
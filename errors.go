@@ -0,0 +1,49 @@
+package nes
+
+import (
+	"fmt"
+
+	"github.com/cd1/nes-emulator/mapper"
+)
+
+// UnsupportedMapperError is returned when a game uses a mapper which isn't
+// emulated.
+type UnsupportedMapperError = mapper.UnsupportedMapperError
+
+// InvalidHeaderError is returned when the header can't describe a valid game.
+type InvalidHeaderError struct {
+	Reason string
+}
+
+func (err InvalidHeaderError) Error() string {
+	return fmt.Sprintf("invalid header: %v", err.Reason)
+}
+
+// TruncatedROMError is returned when the ROM ends before one of the sections
+// announced by the header.
+type TruncatedROMError struct {
+	Section string
+	Size    uint64
+	Read    uint64
+}
+
+func (err TruncatedROMError) Error() string {
+	return fmt.Sprintf("truncated ROM: %v has %v of %v bytes (%v missing)", err.Section, err.Read, err.Size, err.Missing())
+}
+
+// Missing returns how many bytes are missing from the section.
+func (err TruncatedROMError) Missing() uint64 {
+	return err.Size - err.Read
+}
+
+// TruncatedSaveError is returned when the battery save is smaller than the
+// PRG RAM of the cartridge. It's refused so the rest of the RAM, which would
+// start cleared, doesn't replace the save when it's flushed.
+type TruncatedSaveError struct {
+	Size int
+	Read int
+}
+
+func (err TruncatedSaveError) Error() string {
+	return fmt.Sprintf("truncated battery save: it has %v of %v bytes", err.Read, err.Size)
+}
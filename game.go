@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+
+	"github.com/cd1/nes-emulator/mapper"
 )
 
 const (
@@ -12,6 +14,11 @@ const (
 	PRGBankSize    = 16384
 	CHRBankSize    = 8192
 	PlayChoiceSize = 8192
+
+	// MaxROMSize is the largest PRG or CHR ROM accepted. The sizes given in
+	// units by NES 2.0 go up to almost 64 MiB, but the exponent form can
+	// describe much larger ones.
+	MaxROMSize = 64 << 20
 )
 
 var NESMagicNumber = []uint8{0x4e, 0x45, 0x53, 0x1a}
@@ -41,7 +48,7 @@ func (h GameHeader) Flag6() uint8 {
 }
 
 func (h GameHeader) HasPlayChoice() bool {
-	return h[7]&0x02 != 0x00
+	return h.ConsoleType() == ConsoleTypePlayChoice10
 }
 
 func (h GameHeader) Flag7() uint8 {
@@ -70,56 +77,136 @@ type Game struct {
 	Trainer           []uint8
 	PRG               []uint8
 	CHR               []uint8
-	PlayChoiceINSTROM []uint8
-	PlayChoicePROM    []uint8
+	PlayChoiceINSTROM PlayChoiceINSTROM
+	PlayChoicePROM    PlayChoicePROM
+	// problems found in the ROM which don't prevent it from being loaded
+	Warnings []string
 }
 
-func LoadGame(data io.Reader) (*Game, error) {
-	var headerBuf bytes.Buffer
+func (g *Game) warn(format string, args ...interface{}) {
+	g.Warnings = append(g.Warnings, fmt.Sprintf(format, args...))
+}
+
+// diskDudeSignature is the garbage written over the bytes 7-15 of the
+// header by an old ROM tool, which would be read as part of the mapper
+// number.
+var diskDudeSignature = []uint8("DiskDude!")
+
+// readSection reads a section of the ROM with the given size, failing with a
+// TruncatedROMError when the data ends before it.
+func readSection(data io.Reader, name string, size uint64) ([]uint8, error) {
+	var buf bytes.Buffer
+
+	n, err := io.CopyN(&buf, data, int64(size))
+	if err == io.EOF {
+		return nil, TruncatedROMError{Section: name, Size: size, Read: uint64(n)}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
 
-	if _, err := io.CopyN(&headerBuf, data, GameHeaderSize); err != nil {
+func LoadGame(data io.Reader) (*Game, error) {
+	header, err := readSection(data, "header", GameHeaderSize)
+	if err != nil {
 		return nil, err
 	}
 
-	gameHeader := GameHeader(headerBuf.Bytes())
+	game := Game{
+		Header: GameHeader(header),
+	}
+
+	if mn := game.Header.MagicNumber(); !bytes.Equal(mn, NESMagicNumber) {
+		return nil, InvalidHeaderError{fmt.Sprintf("unknown magic number %v", mn)}
+	}
+
+	game.checkHeader()
+
+	if game.Header.PRGROMBytes() == 0 {
+		return nil, InvalidHeaderError{"no PRG ROM"}
+	}
+
+	if size := game.Header.PRGROMBytes(); size > MaxROMSize {
+		return nil, InvalidHeaderError{fmt.Sprintf("PRG ROM of %v bytes is too large", size)}
+	}
 
-	if mn := gameHeader.MagicNumber(); !bytes.Equal(mn, NESMagicNumber) {
-		return nil, fmt.Errorf("invalid magic number: %v", mn)
+	if size := game.Header.CHRROMBytes(); size > MaxROMSize {
+		return nil, InvalidHeaderError{fmt.Sprintf("CHR ROM of %v bytes is too large", size)}
 	}
 
-	var trainerBuf bytes.Buffer
+	if number := game.Header.MapperNumber(); !mapper.IsSupported(number) {
+		return nil, UnsupportedMapperError{Number: number}
+	}
 
-	if gameHeader.HasTrainer() {
-		if _, err := io.CopyN(&trainerBuf, data, TrainerSize); err != nil {
+	if game.Header.HasTrainer() {
+		if game.Trainer, err = readSection(data, "trainer", TrainerSize); err != nil {
 			return nil, err
 		}
 	}
 
-	var prgBuf bytes.Buffer
+	if game.PRG, err = readSection(data, "PRG ROM", game.Header.PRGROMBytes()); err != nil {
+		return nil, err
+	}
 
-	if _, err := io.CopyN(&prgBuf, data, int64(gameHeader.PRGBankCount())*PRGBankSize); err != nil {
+	if game.CHR, err = readSection(data, "CHR ROM", game.Header.CHRROMBytes()); err != nil {
 		return nil, err
 	}
 
-	var chrBuf bytes.Buffer
+	if game.Header.HasPlayChoice() {
+		if game.PlayChoiceINSTROM, err = readSection(data, "PlayChoice INST-ROM", PlayChoiceSize); err != nil {
+			return nil, err
+		}
+
+		// the PROM is missing from most dumps
+		if game.PlayChoicePROM, err = readSection(data, "PlayChoice PROM", PlayChoicePROMSize); err != nil {
+			if truncated, ok := err.(TruncatedROMError); !ok || truncated.Read != 0 {
+				return nil, err
+			}
 
-	if _, err := io.CopyN(&chrBuf, data, int64(gameHeader.CHRBankCount())*CHRBankSize); err != nil {
+			game.warn("the PlayChoice PROM is missing")
+		}
+	}
+
+	trailing, err := io.Copy(io.Discard, data)
+	if err != nil {
 		return nil, err
 	}
 
-	var playChoiceBuf bytes.Buffer
+	if trailing > 0 {
+		game.warn("%v bytes of unexpected data after the ROM", trailing)
+	}
+
+	return &game, nil
+}
 
-	if gameHeader.HasPlayChoice() {
-		if _, err := io.CopyN(&playChoiceBuf, data, PlayChoiceSize); err != nil {
-			return nil, err
+// checkHeader fixes the known header corruptions and records the
+// inconsistencies found as warnings.
+func (g *Game) checkHeader() {
+	h := g.Header
+
+	if bytes.Equal(h[7:GameHeaderSize], diskDudeSignature) {
+		g.warn("header bytes 7-15 contain \"DiskDude!\"; ignoring them")
+
+		for i := 7; i < GameHeaderSize; i++ {
+			h[i] = 0x00
 		}
 	}
 
-	return &Game{
-		Header:            gameHeader,
-		Trainer:           trainerBuf.Bytes(),
-		PRG:               prgBuf.Bytes(),
-		CHR:               chrBuf.Bytes(),
-		PlayChoiceINSTROM: playChoiceBuf.Bytes(),
-	}, nil
+	if h.IsNES20() {
+		if h.HasBattery() && h.PRGNVRAMBytes() == 0 && h.CHRNVRAMBytes() == 0 {
+			g.warn("the game has a battery but no non-volatile memory")
+		}
+	} else {
+		if h[7]&0x0C == 0x04 {
+			g.warn("unknown header format in byte 7: %02X", h[7])
+		}
+
+		for _, b := range h.UnusedPadding() {
+			if b != 0x00 {
+				g.warn("unused header bytes 11-15 are not zero: % X; the mapper number may be wrong", h.UnusedPadding())
+				break
+			}
+		}
+	}
 }
@@ -1,6 +1,8 @@
 package nes
 
 import (
+	"bytes"
+	"errors"
 	"os"
 	"testing"
 )
@@ -19,6 +21,152 @@ func TestLoadGame(t *testing.T) {
 	}
 }
 
+// newTestGame builds an empty game with the given bank counts, in the same
+// format read by LoadGame.
+func newTestGame(t testing.TB, prgBankCount uint8, chrBankCount uint8) *Game {
+	header := make([]uint8, GameHeaderSize)
+	copy(header, NESMagicNumber)
+	header[4] = prgBankCount
+	header[5] = chrBankCount
+
+	data := append(header, make([]uint8, int(prgBankCount)*PRGBankSize+int(chrBankCount)*CHRBankSize)...)
+
+	game, err := LoadGame(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return game
+}
+
+func TestLoadGame_Truncated(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag6   uint8
+		size    int
+		section string
+		read    uint64
+	}{
+		{"header", 0x00, 10, "header", 10},
+		{"trainer", 0x04, GameHeaderSize + 100, "trainer", 100},
+		{"PRG ROM", 0x00, GameHeaderSize + PRGBankSize - 1, "PRG ROM", PRGBankSize - 1},
+		{"CHR ROM", 0x00, GameHeaderSize + PRGBankSize, "CHR ROM", 0},
+	}
+
+	for _, test := range tests {
+		data := make([]uint8, GameHeaderSize+TrainerSize+PRGBankSize+CHRBankSize)
+		copy(data, NESMagicNumber)
+		data[4] = 1
+		data[5] = 1
+		data[6] = test.flag6
+
+		_, err := LoadGame(bytes.NewReader(data[:test.size]))
+
+		var truncated TruncatedROMError
+		if !errors.As(err, &truncated) {
+			t.Errorf("unexpected error with a truncated %v; got=%v, want=%T", test.name, err, truncated)
+			continue
+		}
+		if truncated.Section != test.section {
+			t.Errorf("unexpected truncated section; got=%v, want=%v", truncated.Section, test.section)
+		}
+		if truncated.Read != test.read {
+			t.Errorf("unexpected bytes read from the truncated %v; got=%v, want=%v", test.name, truncated.Read, test.read)
+		}
+	}
+}
+
+func TestLoadGame_InvalidHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []uint8
+		want   interface{}
+	}{
+		{"magic number", []uint8{'N', 'E', 'S', 0x00, 0x01}, InvalidHeaderError{}},
+		{"no PRG ROM", []uint8{'N', 'E', 'S', 0x1A, 0x00}, InvalidHeaderError{}},
+		{"unsupported mapper", []uint8{'N', 'E', 'S', 0x1A, 0x01, 0x00, 0xF0, 0xF0}, UnsupportedMapperError{}},
+		// NES 2.0, CHR ROM size in exponent form: 2^63 * 7 bytes
+		{"CHR ROM size", []uint8{'N', 'E', 'S', 0x1A, 0x01, 0xFF, 0x00, 0x08, 0x00, 0xF0}, InvalidHeaderError{}},
+		// NES 2.0, PRG ROM size in exponent form: 2^32 bytes
+		{"PRG ROM size", []uint8{'N', 'E', 'S', 0x1A, 0x80, 0x00, 0x00, 0x08, 0x00, 0x0F}, InvalidHeaderError{}},
+	}
+
+	for _, test := range tests {
+		data := make([]uint8, GameHeaderSize+PRGBankSize)
+		copy(data, test.header)
+
+		_, err := LoadGame(bytes.NewReader(data))
+
+		switch test.want.(type) {
+		case InvalidHeaderError:
+			var invalid InvalidHeaderError
+			if !errors.As(err, &invalid) {
+				t.Errorf("unexpected error with an invalid %v; got=%v, want=%T", test.name, err, invalid)
+			}
+		case UnsupportedMapperError:
+			if err != (UnsupportedMapperError{Number: 0xFF}) {
+				t.Errorf("unexpected error with an %v; got=%v, want=%v", test.name, err, UnsupportedMapperError{Number: 0xFF})
+			}
+		}
+	}
+}
+
+func TestLoadGame_DiskDude(t *testing.T) {
+	header := make([]uint8, GameHeaderSize)
+	copy(header, NESMagicNumber)
+	header[4] = 1
+	// mapper 3, vertical mirroring
+	header[6] = 0x31
+	copy(header[7:], "DiskDude!")
+
+	game, err := LoadGame(bytes.NewReader(append(header, make([]uint8, PRGBankSize)...)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if number := game.Header.MapperNumber(); number != 3 {
+		t.Errorf("unexpected mapper number; got=%v, want=%v", number, 3)
+	}
+	if len(game.Warnings) != 1 {
+		t.Errorf("unexpected warnings; got=%q, want 1 warning", game.Warnings)
+	}
+}
+
+func TestLoadGame_Warnings(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   []uint8
+		trailing int
+	}{
+		{"trailing data", nil, 100},
+		{"unused bytes", []uint8{0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 'X'}, 0},
+		// NES 2.0
+		{"battery without NVRAM", []uint8{0x01, 0x00, 0x02, 0x08}, 0},
+	}
+
+	for _, test := range tests {
+		header := make([]uint8, GameHeaderSize)
+		copy(header, NESMagicNumber)
+		header[4] = 1
+		copy(header[4:], test.header)
+
+		data := append(header, make([]uint8, PRGBankSize+test.trailing)...)
+
+		game, err := LoadGame(bytes.NewReader(data))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(game.Warnings) != 1 {
+			t.Errorf("unexpected warnings with %v; got=%q, want 1 warning", test.name, game.Warnings)
+		}
+	}
+
+	if game := newTestGame(t, 1, 1); len(game.Warnings) != 0 {
+		t.Errorf("a valid game should not have warnings; got=%q", game.Warnings)
+	}
+}
+
 func BenchmarkLoadGame(b *testing.B) {
 	b.StopTimer()
 
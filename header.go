@@ -0,0 +1,309 @@
+package nes
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/cd1/nes-emulator/mapper"
+)
+
+// ConsoleType is the system the game was made for. The types after
+// ConsoleTypePlayChoice10 only exist in NES 2.0 headers.
+type ConsoleType uint8
+
+const (
+	ConsoleTypeNES ConsoleType = iota
+	ConsoleTypeVsSystem
+	ConsoleTypePlayChoice10
+	// famiclone with a CPU that supports the decimal mode
+	ConsoleTypeDecimalFamiclone
+	ConsoleTypeEPSM
+	ConsoleTypeVT01
+	ConsoleTypeVT02
+	ConsoleTypeVT03
+	ConsoleTypeVT09
+	ConsoleTypeVT32
+	ConsoleTypeVT369
+	ConsoleTypeUM6578
+	ConsoleTypeFamicomNetworkSystem
+)
+
+// TimingRegion is the CPU/PPU timing expected by the game.
+type TimingRegion uint8
+
+const (
+	// RP2C02
+	TimingRegionNTSC TimingRegion = iota
+	// RP2C07
+	TimingRegionPAL
+	// works in both NTSC and PAL
+	TimingRegionMultiple
+	// UMC 6527P
+	TimingRegionDendy
+)
+
+// VsPPUType is the PPU used by a Vs. System game, which determines its
+// palette.
+type VsPPUType uint8
+
+const (
+	VsPPUTypeRP2C03B VsPPUType = iota
+	VsPPUTypeRP2C03G
+	VsPPUTypeRP2C04_0001
+	VsPPUTypeRP2C04_0002
+	VsPPUTypeRP2C04_0003
+	VsPPUTypeRP2C04_0004
+	VsPPUTypeRC2C03B
+	VsPPUTypeRC2C03C
+	VsPPUTypeRC2C05_01
+	VsPPUTypeRC2C05_02
+	VsPPUTypeRC2C05_03
+	VsPPUTypeRC2C05_04
+	VsPPUTypeRC2C05_05
+)
+
+// VsHardwareType is the Vs. System board used by a game, including its copy
+// protection.
+type VsHardwareType uint8
+
+const (
+	VsHardwareTypeUnisystem VsHardwareType = iota
+	VsHardwareTypeUnisystemRBIBaseball
+	VsHardwareTypeUnisystemTKOBoxing
+	VsHardwareTypeUnisystemSuperXevious
+	VsHardwareTypeUnisystemIceClimber
+	VsHardwareTypeDualSystem
+	VsHardwareTypeDualSystemRaidOnBungelingBay
+)
+
+// ExpansionDevice is the input device expected by the game. Only the most
+// common ones are named here; the other values are listed in the NES 2.0
+// specification.
+type ExpansionDevice uint8
+
+const (
+	ExpansionDeviceUnspecified ExpansionDevice = iota
+	ExpansionDeviceStandardControllers
+	ExpansionDeviceFourScore
+	ExpansionDeviceFamicomFourPlayersAdapter
+	ExpansionDeviceVsSystem4016
+	ExpansionDeviceVsSystem4017
+	_
+	ExpansionDeviceVsZapper
+	ExpansionDeviceZapper
+	ExpansionDeviceTwoZappers
+	ExpansionDeviceBandaiHyperShot
+	ExpansionDevicePowerPadSideA
+	ExpansionDevicePowerPadSideB
+	ExpansionDeviceFamilyTrainerSideA
+	ExpansionDeviceFamilyTrainerSideB
+)
+
+// IsNES20 checks whether the header is in the NES 2.0 format, an extension
+// of iNES which uses the bytes left unused by it. When it's not, the
+// accessors below interpret the header as iNES.
+func (h GameHeader) IsNES20() bool {
+	return h[7]&0x0C == 0x08
+}
+
+// Mirroring returns the nametable mirroring hardwired in the board.
+func (h GameHeader) Mirroring() mapper.Mirroring {
+	switch {
+	case h[6]&0x08 != 0x00:
+		return mapper.MirroringFourScreen
+	case h[6]&0x01 != 0x00:
+		return mapper.MirroringVertical
+	default:
+		return mapper.MirroringHorizontal
+	}
+}
+
+func (h GameHeader) HasBattery() bool {
+	return h[6]&0x02 != 0x00
+}
+
+// MapperNumber returns the mapper used by the game, which has 8 bits in iNES
+// and 12 bits in NES 2.0.
+func (h GameHeader) MapperNumber() uint16 {
+	number := uint16(h[6]>>4) | uint16(h[7]&0xF0)
+
+	if h.IsNES20() {
+		number |= uint16(h[8]&0x0F) << 8
+	}
+
+	return number
+}
+
+// Submapper returns the variant of the mapper used by the game. It's always
+// 0 in iNES.
+func (h GameHeader) Submapper() uint8 {
+	if !h.IsNES20() {
+		return 0
+	}
+
+	return h[8] >> 4
+}
+
+// romSize calculates the size of a ROM in NES 2.0 from its LSB and MSB
+// (4 bits). When the MSB is 0xF, the LSB is in the exponent-multiplier
+// form EEEEEEMM, so the size is 2^E * (MM*2+1) bytes, or math.MaxUint64 when
+// it doesn't fit; otherwise the size is given in units.
+func romSize(lsb uint8, msb uint8, unit uint64) uint64 {
+	if msb == 0x0F {
+		hi, size := bits.Mul64(uint64(1)<<(lsb>>2), uint64(lsb&0x03)*2+1)
+		if hi != 0 {
+			return math.MaxUint64
+		}
+
+		return size
+	}
+
+	return (uint64(msb)<<8 | uint64(lsb)) * unit
+}
+
+// PRGROMBytes returns the size of the PRG ROM, in bytes.
+func (h GameHeader) PRGROMBytes() uint64 {
+	if !h.IsNES20() {
+		return uint64(h.PRGBankCount()) * PRGBankSize
+	}
+
+	return romSize(h[4], h[9]&0x0F, PRGBankSize)
+}
+
+// CHRROMBytes returns the size of the CHR ROM, in bytes.
+func (h GameHeader) CHRROMBytes() uint64 {
+	if !h.IsNES20() {
+		return uint64(h.CHRBankCount()) * CHRBankSize
+	}
+
+	return romSize(h[5], h[9]>>4, CHRBankSize)
+}
+
+// ramSize calculates the size of a RAM in NES 2.0 from its shift count:
+// 64 << shift bytes, or nothing when the shift count is 0.
+func ramSize(shift uint8) uint64 {
+	if shift == 0 {
+		return 0
+	}
+
+	return 64 << shift
+}
+
+// inesPRGRAMBytes returns the size of the PRG RAM in iNES, where 0 means
+// 8 kiB for compatibility.
+func (h GameHeader) inesPRGRAMBytes() uint64 {
+	if h.PRGRAMSize() == 0 {
+		return PRGRAMSize
+	}
+
+	return uint64(h.PRGRAMSize()) * PRGRAMSize
+}
+
+// PRGRAMBytes returns the size of the volatile PRG RAM, in bytes.
+func (h GameHeader) PRGRAMBytes() uint64 {
+	if !h.IsNES20() {
+		if h.HasBattery() {
+			return 0
+		}
+
+		return h.inesPRGRAMBytes()
+	}
+
+	return ramSize(h[10] & 0x0F)
+}
+
+// PRGNVRAMBytes returns the size of the non-volatile PRG RAM (or EEPROM), in
+// bytes. In iNES, it's the PRG RAM when the game has a battery.
+func (h GameHeader) PRGNVRAMBytes() uint64 {
+	if !h.IsNES20() {
+		if !h.HasBattery() {
+			return 0
+		}
+
+		return h.inesPRGRAMBytes()
+	}
+
+	return ramSize(h[10] >> 4)
+}
+
+// CHRRAMBytes returns the size of the volatile CHR RAM, in bytes. In iNES,
+// the game has 8 kiB of CHR RAM when it has no CHR ROM.
+func (h GameHeader) CHRRAMBytes() uint64 {
+	if !h.IsNES20() {
+		if h.CHRBankCount() != 0 {
+			return 0
+		}
+
+		return CHRBankSize
+	}
+
+	return ramSize(h[11] & 0x0F)
+}
+
+// CHRNVRAMBytes returns the size of the non-volatile CHR RAM, in bytes. It's
+// always 0 in iNES.
+func (h GameHeader) CHRNVRAMBytes() uint64 {
+	if !h.IsNES20() {
+		return 0
+	}
+
+	return ramSize(h[11] >> 4)
+}
+
+func (h GameHeader) ConsoleType() ConsoleType {
+	if !h.IsNES20() {
+		switch {
+		case h[7]&0x01 != 0x00:
+			return ConsoleTypeVsSystem
+		case h[7]&0x02 != 0x00:
+			return ConsoleTypePlayChoice10
+		default:
+			return ConsoleTypeNES
+		}
+	}
+
+	if consoleType := ConsoleType(h[7] & 0x03); consoleType != 0x03 {
+		return consoleType
+	}
+
+	return ConsoleType(h[13] & 0x0F)
+}
+
+func (h GameHeader) TimingRegion() TimingRegion {
+	if !h.IsNES20() {
+		// only NTSC and PAL are known by iNES
+		return TimingRegion(h.TVSystem() & 0x01)
+	}
+
+	return TimingRegion(h[12] & 0x03)
+}
+
+// VsPPUType returns the PPU used by a Vs. System game. It's only available
+// in NES 2.0.
+func (h GameHeader) VsPPUType() VsPPUType {
+	if !h.IsNES20() || h.ConsoleType() != ConsoleTypeVsSystem {
+		return 0
+	}
+
+	return VsPPUType(h[13] & 0x0F)
+}
+
+// VsHardwareType returns the board used by a Vs. System game. It's only
+// available in NES 2.0.
+func (h GameHeader) VsHardwareType() VsHardwareType {
+	if !h.IsNES20() || h.ConsoleType() != ConsoleTypeVsSystem {
+		return 0
+	}
+
+	return VsHardwareType(h[13] >> 4)
+}
+
+// DefaultExpansionDevice returns the input device expected by the game. It's
+// only available in NES 2.0.
+func (h GameHeader) DefaultExpansionDevice() ExpansionDevice {
+	if !h.IsNES20() {
+		return ExpansionDeviceUnspecified
+	}
+
+	return ExpansionDevice(h[15] & 0x3F)
+}
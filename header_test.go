@@ -0,0 +1,144 @@
+package nes
+
+import (
+	"math"
+	"testing"
+)
+
+func newTestHeader(bytes ...uint8) GameHeader {
+	header := make(GameHeader, GameHeaderSize)
+	copy(header, NESMagicNumber)
+	copy(header[4:], bytes)
+
+	return header
+}
+
+func TestGameHeader_INES(t *testing.T) {
+	// 2x PRG, 1x CHR, mapper 0x14, battery, PAL
+	header := newTestHeader(0x02, 0x01, 0x42, 0x10, 0x00, 0x01)
+
+	if header.IsNES20() {
+		t.Fatal("header should not be detected as NES 2.0")
+	}
+	if mapper := header.MapperNumber(); mapper != 0x14 {
+		t.Errorf("unexpected mapper number; got=%v, want=%v", mapper, 0x14)
+	}
+	if size := header.PRGROMBytes(); size != 2*PRGBankSize {
+		t.Errorf("unexpected PRG ROM size; got=%v, want=%v", size, 2*PRGBankSize)
+	}
+	if size := header.CHRROMBytes(); size != CHRBankSize {
+		t.Errorf("unexpected CHR ROM size; got=%v, want=%v", size, CHRBankSize)
+	}
+	if size := header.CHRRAMBytes(); size != 0 {
+		t.Errorf("unexpected CHR RAM size; got=%v, want=%v", size, 0)
+	}
+	// the PRG RAM size 0 means 8 kiB, which is non-volatile due to the battery
+	if size := header.PRGRAMBytes(); size != 0 {
+		t.Errorf("unexpected PRG RAM size; got=%v, want=%v", size, 0)
+	}
+	if size := header.PRGNVRAMBytes(); size != PRGRAMSize {
+		t.Errorf("unexpected PRG NVRAM size; got=%v, want=%v", size, PRGRAMSize)
+	}
+	if region := header.TimingRegion(); region != TimingRegionPAL {
+		t.Errorf("unexpected timing region; got=%v, want=%v", region, TimingRegionPAL)
+	}
+	if console := header.ConsoleType(); console != ConsoleTypeNES {
+		t.Errorf("unexpected console type; got=%v, want=%v", console, ConsoleTypeNES)
+	}
+}
+
+func TestGameHeader_NES20(t *testing.T) {
+	header := newTestHeader(
+		0x02, // PRG ROM LSB
+		0x01, // CHR ROM LSB
+		0x52, // mapper D0-3, battery
+		0x49, // mapper D4-7, NES 2.0, Vs. System
+		0x31, // submapper, mapper D8-11
+		0x21, // CHR ROM MSB, PRG ROM MSB
+		0x97, // PRG NVRAM, PRG RAM shift counts
+		0x07, // CHR RAM shift count
+		0x03, // Dendy
+		0x52, // Vs. Dual System, RP2C04-0001
+		0x00, // miscellaneous ROMs
+		0x08, // Zapper
+	)
+
+	if !header.IsNES20() {
+		t.Fatal("header should be detected as NES 2.0")
+	}
+	if mapper := header.MapperNumber(); mapper != 0x145 {
+		t.Errorf("unexpected mapper number; got=%03X, want=%03X", mapper, 0x145)
+	}
+	if submapper := header.Submapper(); submapper != 3 {
+		t.Errorf("unexpected submapper; got=%v, want=%v", submapper, 3)
+	}
+	if size := header.PRGROMBytes(); size != 0x102*PRGBankSize {
+		t.Errorf("unexpected PRG ROM size; got=%v, want=%v", size, 0x102*PRGBankSize)
+	}
+	if size := header.CHRROMBytes(); size != 0x201*CHRBankSize {
+		t.Errorf("unexpected CHR ROM size; got=%v, want=%v", size, 0x201*CHRBankSize)
+	}
+	if size := header.PRGRAMBytes(); size != 64<<7 {
+		t.Errorf("unexpected PRG RAM size; got=%v, want=%v", size, 64<<7)
+	}
+	if size := header.PRGNVRAMBytes(); size != 64<<9 {
+		t.Errorf("unexpected PRG NVRAM size; got=%v, want=%v", size, 64<<9)
+	}
+	if size := header.CHRRAMBytes(); size != 64<<7 {
+		t.Errorf("unexpected CHR RAM size; got=%v, want=%v", size, 64<<7)
+	}
+	if size := header.CHRNVRAMBytes(); size != 0 {
+		t.Errorf("unexpected CHR NVRAM size; got=%v, want=%v", size, 0)
+	}
+	if region := header.TimingRegion(); region != TimingRegionDendy {
+		t.Errorf("unexpected timing region; got=%v, want=%v", region, TimingRegionDendy)
+	}
+	if console := header.ConsoleType(); console != ConsoleTypeVsSystem {
+		t.Errorf("unexpected console type; got=%v, want=%v", console, ConsoleTypeVsSystem)
+	}
+	if ppu := header.VsPPUType(); ppu != VsPPUTypeRP2C04_0001 {
+		t.Errorf("unexpected Vs. PPU type; got=%v, want=%v", ppu, VsPPUTypeRP2C04_0001)
+	}
+	if hardware := header.VsHardwareType(); hardware != VsHardwareTypeDualSystem {
+		t.Errorf("unexpected Vs. hardware type; got=%v, want=%v", hardware, VsHardwareTypeDualSystem)
+	}
+	if device := header.DefaultExpansionDevice(); device != ExpansionDeviceZapper {
+		t.Errorf("unexpected default expansion device; got=%v, want=%v", device, ExpansionDeviceZapper)
+	}
+}
+
+func TestGameHeader_ExponentMultiplier(t *testing.T) {
+	tests := []struct {
+		lsb  uint8
+		want uint64
+	}{
+		{0x00, 1},
+		{0x01, 3},
+		{0x0A, 4 * 5},
+		{0x4B, (1 << 18) * 7},
+		{0xFC, 1 << 63},
+		{0xFD, math.MaxUint64},
+	}
+
+	for _, test := range tests {
+		header := newTestHeader(test.lsb, test.lsb, 0x00, 0x08, 0x00, 0xFF)
+
+		if size := header.PRGROMBytes(); size != test.want {
+			t.Errorf("unexpected PRG ROM size for %02X; got=%v, want=%v", test.lsb, size, test.want)
+		}
+		if size := header.CHRROMBytes(); size != test.want {
+			t.Errorf("unexpected CHR ROM size for %02X; got=%v, want=%v", test.lsb, size, test.want)
+		}
+	}
+}
+
+func TestGameHeader_ExtendedConsoleType(t *testing.T) {
+	header := newTestHeader(0x01, 0x00, 0x00, 0x0B, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03)
+
+	if console := header.ConsoleType(); console != ConsoleTypeDecimalFamiclone {
+		t.Errorf("unexpected console type; got=%v, want=%v", console, ConsoleTypeDecimalFamiclone)
+	}
+	if header.HasPlayChoice() {
+		t.Error("an extended console type should not be detected as PlayChoice")
+	}
+}
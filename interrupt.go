@@ -0,0 +1,69 @@
+package nes
+
+import "github.com/cd1/nes-emulator/cpu"
+
+// IRQSource identifies a device connected to the CPU /IRQ line. The line is
+// shared (wired-OR) so it stays asserted while at least one source holds it.
+type IRQSource uint8
+
+const (
+	IRQSourceAPUFrameCounter IRQSource = 1 << iota
+	IRQSourceAPUDMC
+	IRQSourceMapper
+)
+
+// SetNMI drives the CPU /NMI line. The NMI is edge-triggered: it's only
+// requested when the line goes from released to asserted, and it'll be
+// handled before the next instruction is executed.
+func (nes *NES) SetNMI(asserted bool) {
+	if asserted && !nes.nmiLine {
+		nes.nmiPending = true
+	}
+
+	nes.nmiLine = asserted
+}
+
+// SetIRQ drives the CPU /IRQ line on behalf of source. The IRQ is
+// level-triggered: it's handled before every instruction while the line is
+// asserted and the interrupt flag is clear, so the source must release it
+// once it's been acknowledged.
+func (nes *NES) SetIRQ(source IRQSource, asserted bool) {
+	if asserted {
+		nes.irqLines |= source
+	} else {
+		nes.irqLines &= ^source
+	}
+}
+
+// IsIRQAsserted checks whether any source is holding the /IRQ line.
+func (nes *NES) IsIRQAsserted() bool {
+	return nes.irqLines != 0
+}
+
+// pollInterrupts handles a pending interrupt, if any, and returns the number
+// of cycles taken. NMI has priority over IRQ.
+func (nes *NES) pollInterrupts() uint8 {
+	if nes.Cartridge != nil {
+		nes.SetIRQ(IRQSourceMapper, nes.Cartridge.Mapper.IRQ())
+	}
+
+	if nes.nmiPending {
+		nes.nmiPending = false
+		return nes.interrupt(cpu.NMIVectorAddress)
+	}
+
+	if nes.IsIRQAsserted() && !nes.IsStatusInterrupt() {
+		return nes.interrupt(cpu.IRQVectorAddress)
+	}
+
+	return 0
+}
+
+func (nes *NES) interrupt(vectorAddress uint16) uint8 {
+	// the next op code is fetched twice and discarded before the interrupt
+	// sequence starts
+	nes.dummyRead(nes.CPU.ProgramCounter)
+	nes.dummyRead(nes.CPU.ProgramCounter)
+
+	return cpu.Interrupt(nes, vectorAddress)
+}
@@ -0,0 +1,76 @@
+package nes
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/cd1/nes-emulator/cpu"
+)
+
+// JamPolicy defines what happens when the CPU executes a JAM operation.
+type JamPolicy uint8
+
+const (
+	// JamPolicyHalt stops the CPU like the real hardware: no more operations
+	// are fetched until the system is reset, but the time keeps passing. The
+	// jam is logged, as nothing else shows it.
+	JamPolicyHalt JamPolicy = iota
+	// JamPolicyError makes NES.Step return a cpu.CPUJammedError.
+	JamPolicyError
+	// JamPolicyNOP treats JAM as a 1-byte NOP.
+	JamPolicyNOP
+)
+
+// number of cycles that pass on each step while the CPU is jammed
+const jammedCycles = 1
+
+// ParseJamPolicy converts the name of a policy ("halt", "error" or "nop") into
+// its value.
+func ParseJamPolicy(name string) (JamPolicy, error) {
+	switch name {
+	case "halt":
+		return JamPolicyHalt, nil
+	case "error":
+		return JamPolicyError, nil
+	case "nop":
+		return JamPolicyNOP, nil
+	default:
+		return 0, fmt.Errorf("invalid jam policy: %v", name)
+	}
+}
+
+func (p JamPolicy) String() string {
+	switch p {
+	case JamPolicyHalt:
+		return "halt"
+	case JamPolicyError:
+		return "error"
+	case JamPolicyNOP:
+		return "nop"
+	default:
+		return fmt.Sprintf("[jam policy = %d]", p)
+	}
+}
+
+// IsJammed checks if the CPU has stopped after executing a JAM operation.
+func (nes *NES) IsJammed() bool {
+	return nes.jammed
+}
+
+// Jam handles a JAM operation according to the configured JamPolicy.
+func (nes *NES) Jam(op cpu.Operation) error {
+	switch nes.JamPolicy {
+	case JamPolicyError:
+		return cpu.CPUJammedError{
+			OpCode:         op.Code(),
+			ProgramCounter: nes.CPU.ProgramCounter,
+		}
+	case JamPolicyNOP:
+		nes.IncrementProgramCounter(op.Size())
+	default:
+		nes.jammed = true
+		log.Printf("the CPU jammed with the op code %02X at $%04X; it's halted until a reset", op.Code(), nes.CPU.ProgramCounter)
+	}
+
+	return nil
+}
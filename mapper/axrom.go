@@ -0,0 +1,41 @@
+package mapper
+
+const axromUpperNametable = 0x10
+
+// AxROM (mapper 7) switches the 32 kiB PRG bank and selects which nametable
+// is used in single screen mirroring.
+type axrom struct {
+	discreteMapper
+}
+
+func init() {
+	Register(7, "AxROM", newAxROM)
+}
+
+func newAxROM(board *Board) (Mapper, error) {
+	// only AOROM has bus conflicts, and it can't be told apart from ANROM
+	// without a submapper
+	base, err := newDiscreteMapper("AxROM", board, 2*prgBankSize, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &axrom{base}, nil
+}
+
+func (m *axrom) ReadCPU(address uint16) (uint8, bool) {
+	return m.readCPU(address, int(m.latch&0x0F))
+}
+
+func (m *axrom) WriteCPU(address uint16, value uint8) {
+	rom, _ := m.ReadCPU(address)
+	m.writeCPU(address, value, rom)
+}
+
+func (m *axrom) Mirroring() Mirroring {
+	if m.latch&axromUpperNametable != 0x00 {
+		return MirroringSingleScreenUpper
+	}
+
+	return MirroringSingleScreenLower
+}
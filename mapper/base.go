@@ -0,0 +1,103 @@
+package mapper
+
+import "io"
+
+const (
+	prgBankSize = 0x4000
+	chrBankSize = 0x2000
+)
+
+// baseMapper has the behaviour shared by most mappers: the PRG RAM in
+// $6000-$7FFF, 8 kiB of CHR without banking and the mirroring hardwired in
+// the board.
+type baseMapper struct {
+	board *Board
+}
+
+// readBank reads from the bank of bankSize bytes in data. The bank number
+// wraps around the banks available, as the unused bank bits aren't connected.
+func readBank(data []uint8, bank int, bankSize int, address uint16) uint8 {
+	return data[bankAddress(data, bank, bankSize, address)]
+}
+
+func writeBank(data []uint8, bank int, bankSize int, address uint16, value uint8) {
+	data[bankAddress(data, bank, bankSize, address)] = value
+}
+
+func bankAddress(data []uint8, bank int, bankSize int, address uint16) int {
+	return (bank*bankSize + int(address)&(bankSize-1)) % len(data)
+}
+
+// bankCount returns how many banks of bankSize bytes fit in data (at least
+// one).
+func bankCount(data []uint8, bankSize int) int {
+	if count := len(data) / bankSize; count > 0 {
+		return count
+	}
+
+	return 1
+}
+
+func (m *baseMapper) readPRGRAM(address uint16) (uint8, bool) {
+	if len(m.board.PRGRAM) == 0 {
+		return 0x00, false
+	}
+
+	return m.board.PRGRAM[int(address-PRGRAMStart)%len(m.board.PRGRAM)], true
+}
+
+func (m *baseMapper) writePRGRAM(address uint16, value uint8) {
+	if len(m.board.PRGRAM) == 0 {
+		return
+	}
+
+	m.board.PRGRAM[int(address-PRGRAMStart)%len(m.board.PRGRAM)] = value
+}
+
+func (m *baseMapper) ReadPPU(address uint16) uint8 {
+	return readBank(m.board.CHR, 0, chrBankSize, address)
+}
+
+func (m *baseMapper) WritePPU(address uint16, value uint8) {
+	if m.board.CHRRAM {
+		writeBank(m.board.CHR, 0, chrBankSize, address, value)
+	}
+}
+
+func (m *baseMapper) Mirroring() Mirroring {
+	return m.board.Mirroring
+}
+
+func (m *baseMapper) IRQ() bool {
+	return false
+}
+
+// SaveState writes the RAM in the board; mappers with registers must save
+// them before calling it.
+func (m *baseMapper) SaveState(w io.Writer) error {
+	if _, err := w.Write(m.board.PRGRAM); err != nil {
+		return err
+	}
+
+	if m.board.CHRRAM {
+		if _, err := w.Write(m.board.CHR); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *baseMapper) LoadState(r io.Reader) error {
+	if _, err := io.ReadFull(r, m.board.PRGRAM); err != nil {
+		return err
+	}
+
+	if m.board.CHRRAM {
+		if _, err := io.ReadFull(r, m.board.CHR); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package mapper
+
+// CNROM (mapper 3) switches the 8 kiB CHR bank; the PRG ROM is fixed, as in
+// NROM.
+type cnrom struct {
+	discreteMapper
+}
+
+func init() {
+	Register(3, "CNROM", newCNROM)
+}
+
+func newCNROM(board *Board) (Mapper, error) {
+	if size := len(board.PRG); size != prgBankSize && size != 2*prgBankSize {
+		return nil, InvalidMemorySizeError{"CNROM", "PRG ROM", size}
+	}
+
+	base, err := newDiscreteMapper("CNROM", board, prgBankSize, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cnrom{base}, nil
+}
+
+func (m *cnrom) ReadCPU(address uint16) (uint8, bool) {
+	if address < PRGROMStart {
+		return m.readCPU(address, 0)
+	}
+
+	// 16 kiB are mirrored
+	return readBank(m.board.PRG, 0, len(m.board.PRG), address), true
+}
+
+func (m *cnrom) WriteCPU(address uint16, value uint8) {
+	rom, _ := m.ReadCPU(address)
+	m.writeCPU(address, value, rom)
+}
+
+func (m *cnrom) ReadPPU(address uint16) uint8 {
+	return readBank(m.board.CHR, int(m.latch), chrBankSize, address)
+}
+
+func (m *cnrom) WritePPU(address uint16, value uint8) {
+	if m.board.CHRRAM {
+		writeBank(m.board.CHR, int(m.latch), chrBankSize, address, value)
+	}
+}
@@ -0,0 +1,39 @@
+package mapper
+
+// Color Dreams (mapper 11) switches the 32 kiB PRG bank (bits 0-1) and the
+// 8 kiB CHR bank (bits 4-7).
+type colorDreams struct {
+	discreteMapper
+}
+
+func init() {
+	Register(11, "Color Dreams", newColorDreams)
+}
+
+func newColorDreams(board *Board) (Mapper, error) {
+	base, err := newDiscreteMapper("Color Dreams", board, 2*prgBankSize, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &colorDreams{base}, nil
+}
+
+func (m *colorDreams) ReadCPU(address uint16) (uint8, bool) {
+	return m.readCPU(address, int(m.latch&0x03))
+}
+
+func (m *colorDreams) WriteCPU(address uint16, value uint8) {
+	rom, _ := m.ReadCPU(address)
+	m.writeCPU(address, value, rom)
+}
+
+func (m *colorDreams) ReadPPU(address uint16) uint8 {
+	return readBank(m.board.CHR, int(m.latch>>4), chrBankSize, address)
+}
+
+func (m *colorDreams) WritePPU(address uint16, value uint8) {
+	if m.board.CHRRAM {
+		writeBank(m.board.CHR, int(m.latch>>4), chrBankSize, address, value)
+	}
+}
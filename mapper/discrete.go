@@ -0,0 +1,94 @@
+package mapper
+
+import "io"
+
+// submappers shared by the discrete logic boards (NES 2.0)
+const (
+	discreteSubmapperNoBusConflicts = 1
+	discreteSubmapperBusConflicts   = 2
+)
+
+// discreteMapper has the behaviour shared by the boards made of discrete
+// logic chips, which have a single register (a latch) written through any
+// address in $8000-$FFFF.
+//
+// The ROM stays enabled while the register is written, so both drive the
+// data bus in boards with bus conflicts: the value latched is the AND of
+// the value written and the one in the ROM.
+type discreteMapper struct {
+	baseMapper
+
+	latch        uint8
+	busConflicts bool
+}
+
+// newDiscreteMapper creates the base of a discrete mapper. The board has bus
+// conflicts when defaultBusConflicts is set, unless its submapper says
+// otherwise.
+func newDiscreteMapper(name string, board *Board, bankSize int, defaultBusConflicts bool) (discreteMapper, error) {
+	if size := len(board.PRG); size == 0 || size%bankSize != 0 {
+		return discreteMapper{}, InvalidMemorySizeError{name, "PRG ROM", size}
+	}
+
+	busConflicts := defaultBusConflicts
+
+	switch board.Submapper {
+	case discreteSubmapperNoBusConflicts:
+		busConflicts = false
+	case discreteSubmapperBusConflicts:
+		busConflicts = true
+	}
+
+	return discreteMapper{
+		baseMapper:   baseMapper{board},
+		busConflicts: busConflicts,
+	}, nil
+}
+
+// readCPU reads from the PRG ROM, where the 32 kiB bank is mapped, or from
+// the PRG RAM.
+func (m *discreteMapper) readCPU(address uint16, bank int) (uint8, bool) {
+	switch {
+	case address >= PRGROMStart:
+		return readBank(m.board.PRG, bank, 2*prgBankSize, address), true
+	case address >= PRGRAMStart:
+		return m.readPRGRAM(address)
+	default:
+		return 0x00, false
+	}
+}
+
+// writeCPU latches value when it's written to $8000-$FFFF. rom is the value
+// driven by the ROM in address, for the bus conflicts.
+func (m *discreteMapper) writeCPU(address uint16, value uint8, rom uint8) {
+	switch {
+	case address >= PRGROMStart:
+		if m.busConflicts {
+			value &= rom
+		}
+
+		m.latch = value
+	case address >= PRGRAMStart:
+		m.writePRGRAM(address, value)
+	}
+}
+
+func (m *discreteMapper) SaveState(w io.Writer) error {
+	if _, err := w.Write([]uint8{m.latch}); err != nil {
+		return err
+	}
+
+	return m.baseMapper.SaveState(w)
+}
+
+func (m *discreteMapper) LoadState(r io.Reader) error {
+	var latch [1]uint8
+
+	if _, err := io.ReadFull(r, latch[:]); err != nil {
+		return err
+	}
+
+	m.latch = latch[0]
+
+	return m.baseMapper.LoadState(r)
+}
@@ -0,0 +1,21 @@
+package mapper
+
+import "fmt"
+
+type UnsupportedMapperError struct {
+	Number uint16
+}
+
+func (err UnsupportedMapperError) Error() string {
+	return fmt.Sprintf("unsupported mapper: %v", err.Number)
+}
+
+type InvalidMemorySizeError struct {
+	Mapper string
+	Memory string
+	Size   int
+}
+
+func (err InvalidMemorySizeError) Error() string {
+	return fmt.Sprintf("invalid %v size for %v: %v bytes", err.Memory, err.Mapper, err.Size)
+}
@@ -0,0 +1,39 @@
+package mapper
+
+// GxROM (mapper 66) switches the 32 kiB PRG bank (bits 4-5) and the 8 kiB
+// CHR bank (bits 0-1).
+type gxrom struct {
+	discreteMapper
+}
+
+func init() {
+	Register(66, "GxROM", newGxROM)
+}
+
+func newGxROM(board *Board) (Mapper, error) {
+	base, err := newDiscreteMapper("GxROM", board, 2*prgBankSize, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gxrom{base}, nil
+}
+
+func (m *gxrom) ReadCPU(address uint16) (uint8, bool) {
+	return m.readCPU(address, int(m.latch>>4&0x03))
+}
+
+func (m *gxrom) WriteCPU(address uint16, value uint8) {
+	rom, _ := m.ReadCPU(address)
+	m.writeCPU(address, value, rom)
+}
+
+func (m *gxrom) ReadPPU(address uint16) uint8 {
+	return readBank(m.board.CHR, int(m.latch&0x03), chrBankSize, address)
+}
+
+func (m *gxrom) WritePPU(address uint16, value uint8) {
+	if m.board.CHRRAM {
+		writeBank(m.board.CHR, int(m.latch&0x03), chrBankSize, address, value)
+	}
+}
@@ -0,0 +1,95 @@
+// Package mapper implements the boards found in NES cartridges, which map
+// the PRG and CHR memories into the CPU and PPU address spaces.
+package mapper
+
+import "io"
+
+// cartridge space in the CPU memory map
+const (
+	CartridgeStart = 0x4020
+	CartridgeEnd   = 0xFFFF
+
+	PRGRAMStart = 0x6000
+	PRGRAMEnd   = 0x7FFF
+
+	PRGROMStart = 0x8000
+	PRGROMEnd   = 0xFFFF
+)
+
+// pattern tables in the PPU memory map
+const (
+	CHRStart = 0x0000
+	CHREnd   = 0x1FFF
+)
+
+// Mirroring is the arrangement of the nametables in the PPU memory map.
+type Mirroring uint8
+
+const (
+	// $2000 = $2400, $2800 = $2C00
+	MirroringHorizontal Mirroring = iota
+	// $2000 = $2800, $2400 = $2C00
+	MirroringVertical
+	// all nametables are the first one
+	MirroringSingleScreenLower
+	// all nametables are the second one
+	MirroringSingleScreenUpper
+	// the cartridge has memory for the other 2 nametables
+	MirroringFourScreen
+)
+
+func (m Mirroring) String() string {
+	switch m {
+	case MirroringHorizontal:
+		return "horizontal"
+	case MirroringVertical:
+		return "vertical"
+	case MirroringSingleScreenLower:
+		return "single screen (lower)"
+	case MirroringSingleScreenUpper:
+		return "single screen (upper)"
+	case MirroringFourScreen:
+		return "four screen"
+	default:
+		return "unknown"
+	}
+}
+
+// Board holds the memories of a cartridge, which are connected to the
+// consoles through its mapper.
+type Board struct {
+	PRG []uint8
+	// CHR is either ROM or RAM, according to CHRRAM
+	CHR    []uint8
+	CHRRAM bool
+	PRGRAM []uint8
+	// Mirroring is the one hardwired in the board; some mappers can change it
+	Mirroring Mirroring
+	Submapper uint8
+}
+
+// Mapper connects the memories of a Board to the CPU ($4020-$FFFF) and to the
+// PPU ($0000-$1FFF).
+type Mapper interface {
+	// ReadCPU reads from address. When the mapper doesn't drive the data bus
+	// in address (e.g. no memory is mapped there), false is returned.
+	ReadCPU(address uint16) (uint8, bool)
+	WriteCPU(address uint16, value uint8)
+	ReadPPU(address uint16) uint8
+	WritePPU(address uint16, value uint8)
+
+	Mirroring() Mirroring
+	// IRQ checks whether the mapper is asserting the CPU /IRQ line.
+	IRQ() bool
+
+	// SaveState writes the registers and the RAM of the mapper to w, so
+	// they can be restored later by LoadState.
+	SaveState(w io.Writer) error
+	LoadState(r io.Reader) error
+}
+
+// Clocked is implemented by the mappers which watch the CPU clock (M2).
+type Clocked interface {
+	// ClockCPU is called at the beginning of every CPU cycle.
+	ClockCPU()
+}
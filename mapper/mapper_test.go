@@ -0,0 +1,150 @@
+package mapper
+
+import (
+	"bytes"
+	"testing"
+)
+
+// newTestBoard creates a board whose PRG and CHR banks are filled with their
+// bank numbers, so the banks mapped can be identified by reading them.
+func newTestBoard(prgSize int, prgBankSize int, chrSize int, chrBankSize int) *Board {
+	board := &Board{
+		PRG:    make([]uint8, prgSize),
+		CHR:    make([]uint8, chrSize),
+		PRGRAM: make([]uint8, 0x2000),
+	}
+
+	for i := range board.PRG {
+		board.PRG[i] = uint8(i / prgBankSize)
+	}
+
+	for i := range board.CHR {
+		board.CHR[i] = uint8(i / chrBankSize)
+	}
+
+	return board
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(0xFFF, newTestBoard(0x4000, 0x4000, 0x2000, 0x2000)); err != (UnsupportedMapperError{0xFFF}) {
+		t.Errorf("unexpected error creating an unsupported mapper; got=%v, want=%v", err, UnsupportedMapperError{0xFFF})
+	}
+
+	if !IsSupported(0) {
+		t.Error("NROM should be supported")
+	}
+	if name := Name(0); name != "NROM" {
+		t.Errorf("unexpected name of mapper 0; got=%v, want=%v", name, "NROM")
+	}
+}
+
+func TestNROM(t *testing.T) {
+	board := newTestBoard(0x8000, 0x4000, 0x2000, 0x2000)
+	board.PRG[0x4000] = 0xAB
+
+	m, err := New(0, board)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if value, _ := m.ReadCPU(0xC000); value != 0xAB {
+		t.Errorf("unexpected value read from the second PRG bank; got=%02X, want=%02X", value, 0xAB)
+	}
+	if _, ok := m.ReadCPU(0x5000); ok {
+		t.Error("NROM should not drive the data bus in $5000")
+	}
+
+	m.WriteCPU(0x6123, 0x45)
+
+	if value, ok := m.ReadCPU(0x6123); !ok || value != 0x45 {
+		t.Errorf("unexpected value read from the PRG RAM; got=%02X, want=%02X", value, 0x45)
+	}
+
+	m.WriteCPU(0x8000, 0x67)
+
+	if value, _ := m.ReadCPU(0x8000); value != 0x00 {
+		t.Errorf("the PRG ROM should not be written; got=%02X, want=%02X", value, 0x00)
+	}
+
+	if _, err := New(0, newTestBoard(0x2000, 0x2000, 0x2000, 0x2000)); err == nil {
+		t.Error("NROM should not accept 8 kiB of PRG ROM")
+	}
+}
+
+func TestNROM_CHRRAM(t *testing.T) {
+	board := newTestBoard(0x4000, 0x4000, 0x2000, 0x2000)
+
+	m, err := New(0, board)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.WritePPU(0x1234, 0x56)
+
+	if value := m.ReadPPU(0x1234); value != 0x00 {
+		t.Errorf("the CHR ROM should not be written; got=%02X, want=%02X", value, 0x00)
+	}
+
+	board.CHRRAM = true
+	m.WritePPU(0x1234, 0x56)
+
+	if value := m.ReadPPU(0x1234); value != 0x56 {
+		t.Errorf("unexpected value read from the CHR RAM; got=%02X, want=%02X", value, 0x56)
+	}
+}
+
+func TestCNROM(t *testing.T) {
+	board := newTestBoard(0x4000, 0x4000, 0x8000, 0x2000)
+	board.PRG[0x0001] = 0x03
+
+	m, err := New(3, board)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the value written is ANDed with the PRG ROM (bus conflicts)
+	m.WriteCPU(0xC001, 0x02)
+
+	if value := m.ReadPPU(0x0000); value != 0x02 {
+		t.Errorf("unexpected CHR bank; got=%v, want=%v", value, 0x02)
+	}
+
+	for _, size := range []int{0x2000, 0xC000, 0x10000} {
+		if _, err := New(3, newTestBoard(size, 0x4000, 0x8000, 0x2000)); err == nil {
+			t.Errorf("CNROM should not accept %v kiB of PRG ROM", size/1024)
+		}
+	}
+}
+
+func TestBaseMapper_State(t *testing.T) {
+	board := newTestBoard(0x4000, 0x4000, 0x2000, 0x2000)
+	board.CHRRAM = true
+
+	m, err := New(0, board)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.WriteCPU(0x6000, 0x12)
+	m.WritePPU(0x0000, 0x34)
+
+	var state bytes.Buffer
+
+	if err := m.SaveState(&state); err != nil {
+		t.Fatal(err)
+	}
+
+	m.WriteCPU(0x6000, 0x00)
+	m.WritePPU(0x0000, 0x00)
+
+	if err := m.LoadState(&state); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, _ := m.ReadCPU(0x6000); value != 0x12 {
+		t.Errorf("unexpected PRG RAM after restoring the state; got=%02X, want=%02X", value, 0x12)
+	}
+	if value := m.ReadPPU(0x0000); value != 0x34 {
+		t.Errorf("unexpected CHR RAM after restoring the state; got=%02X, want=%02X", value, 0x34)
+	}
+}
@@ -0,0 +1,285 @@
+package mapper
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MMC1 submappers (NES 2.0)
+const (
+	mmc1SubmapperSUROM = 1
+	mmc1SubmapperSOROM = 2
+	mmc1SubmapperSXROM = 3
+	mmc1SubmapperSEROM = 5
+)
+
+const (
+	mmc1ShiftReset = 0x10
+
+	mmc1ControlPRGModeFixLast = 0x0C
+	mmc1ControlCHR4K          = 0x10
+
+	mmc1PRGRAMDisable = 0x10
+
+	// PRG ROM in SUROM and SXROM, which is split in 2 halves of 256 kiB
+	mmc1LargePRGSize = 0x80000
+	// PRG RAM in SXROM, which is split in 4 banks of 8 kiB
+	mmc1LargePRGRAMSize = 0x8000
+)
+
+// MMC1 (mapper 1), used by the SxROM boards, is configured through a serial
+// port: 5 writes to $8000-$FFFF, each with one bit, fill a shift register
+// which is then copied to one of the internal registers, selected by the
+// address of the last write.
+type mmc1 struct {
+	baseMapper
+
+	registers mmc1Registers
+	variant   uint8
+
+	// MMC1 ignores a write done in the cycle after another one, as
+	// read-modify-write operations do
+	writeInCycle     bool
+	writeInLastCycle bool
+}
+
+// mmc1Registers holds the state of MMC1 saved by SaveState.
+type mmc1Registers struct {
+	Shift   uint8
+	Control uint8
+	CHR0    uint8
+	CHR1    uint8
+	PRG     uint8
+	// the CHR register used in 4 kiB mode depends on the last pattern table
+	// accessed by the PPU, which also selects the PRG ROM and RAM banks in
+	// the larger boards
+	PPUA12 bool
+}
+
+func init() {
+	Register(1, "MMC1", newMMC1)
+}
+
+func newMMC1(board *Board) (Mapper, error) {
+	if size := len(board.PRG); size == 0 || size%prgBankSize != 0 {
+		return nil, InvalidMemorySizeError{"MMC1", "PRG ROM", size}
+	}
+
+	m := &mmc1{
+		baseMapper: baseMapper{board},
+		registers: mmc1Registers{
+			Shift:   mmc1ShiftReset,
+			Control: mmc1ControlPRGModeFixLast,
+		},
+		variant: board.Submapper,
+	}
+
+	if m.variant == 0 {
+		// older dumps only tell the boards apart by their memory sizes
+		switch {
+		case len(board.PRGRAM) >= mmc1LargePRGRAMSize:
+			m.variant = mmc1SubmapperSXROM
+		case len(board.PRG) >= mmc1LargePRGSize:
+			m.variant = mmc1SubmapperSUROM
+		}
+	}
+
+	return m, nil
+}
+
+// ClockCPU forgets the writes done before the last cycle.
+func (m *mmc1) ClockCPU() {
+	m.writeInLastCycle = m.writeInCycle
+	m.writeInCycle = false
+}
+
+func (m *mmc1) ReadCPU(address uint16) (uint8, bool) {
+	switch {
+	case address >= PRGROMStart:
+		return m.board.PRG[m.prgAddress(address)], true
+	case address >= PRGRAMStart:
+		if !m.isPRGRAMEnabled() || len(m.board.PRGRAM) == 0 {
+			return 0x00, false
+		}
+
+		return m.board.PRGRAM[m.prgRAMAddress(address)], true
+	default:
+		return 0x00, false
+	}
+}
+
+func (m *mmc1) WriteCPU(address uint16, value uint8) {
+	switch {
+	case address >= PRGROMStart:
+		m.writeSerial(address, value)
+	case address >= PRGRAMStart:
+		if m.isPRGRAMEnabled() && len(m.board.PRGRAM) > 0 {
+			m.board.PRGRAM[m.prgRAMAddress(address)] = value
+		}
+	}
+}
+
+func (m *mmc1) writeSerial(address uint16, value uint8) {
+	ignored := m.writeInLastCycle
+	m.writeInCycle = true
+
+	if ignored {
+		return
+	}
+
+	r := &m.registers
+
+	if value&0x80 != 0x00 {
+		r.Shift = mmc1ShiftReset
+		r.Control |= mmc1ControlPRGModeFixLast
+		return
+	}
+
+	// the register is full when the initial bit reaches bit 0
+	full := r.Shift&0x01 != 0x00
+	r.Shift = r.Shift>>1 | (value&0x01)<<4
+
+	if !full {
+		return
+	}
+
+	switch address & 0xE000 {
+	case 0x8000:
+		r.Control = r.Shift
+	case 0xA000:
+		r.CHR0 = r.Shift
+	case 0xC000:
+		r.CHR1 = r.Shift
+	case 0xE000:
+		r.PRG = r.Shift
+	}
+
+	r.Shift = mmc1ShiftReset
+}
+
+// chrRegister returns the CHR bank register used by the last pattern table
+// accessed by the PPU.
+func (m *mmc1) chrRegister() uint8 {
+	if m.registers.Control&mmc1ControlCHR4K != 0x00 && m.registers.PPUA12 {
+		return m.registers.CHR1
+	}
+
+	return m.registers.CHR0
+}
+
+func (m *mmc1) isPRGRAMEnabled() bool {
+	return m.registers.PRG&mmc1PRGRAMDisable == 0x00
+}
+
+func (m *mmc1) prgAddress(address uint16) int {
+	if m.variant == mmc1SubmapperSEROM {
+		// the PRG ROM is fixed
+		return bankAddress(m.board.PRG, 0, 2*prgBankSize, address)
+	}
+
+	bank := int(m.registers.PRG & 0x0F)
+	last := 0x0F
+
+	switch m.registers.Control & 0x0C {
+	case 0x00, 0x04:
+		// 32 kiB
+		return m.prgHalf() + bankAddress(m.board.PRG[:m.prgHalfSize()], bank>>1, 2*prgBankSize, address)
+	case 0x08:
+		// first bank fixed in $8000
+		if address < 0xC000 {
+			bank = 0
+		}
+	case 0x0C:
+		// last bank fixed in $C000
+		if address >= 0xC000 {
+			bank = last
+		}
+	}
+
+	return m.prgHalf() + bankAddress(m.board.PRG[:m.prgHalfSize()], bank, prgBankSize, address)
+}
+
+// prgHalfSize returns the size of the PRG ROM addressed by the PRG
+// register, which is at most 256 kiB.
+func (m *mmc1) prgHalfSize() int {
+	if len(m.board.PRG) > mmc1LargePRGSize/2 {
+		return mmc1LargePRGSize / 2
+	}
+
+	return len(m.board.PRG)
+}
+
+// prgHalf returns the offset of the 256 kiB half of the PRG ROM selected by
+// the CHR register in SUROM and SXROM.
+func (m *mmc1) prgHalf() int {
+	if len(m.board.PRG) <= mmc1LargePRGSize/2 {
+		return 0
+	}
+
+	return int(m.chrRegister()>>4&0x01) * mmc1LargePRGSize / 2
+}
+
+func (m *mmc1) prgRAMAddress(address uint16) int {
+	var bank int
+
+	switch m.variant {
+	case mmc1SubmapperSXROM:
+		bank = int(m.chrRegister() >> 2 & 0x03)
+	case mmc1SubmapperSOROM:
+		bank = int(m.chrRegister() >> 3 & 0x01)
+	}
+
+	return bankAddress(m.board.PRGRAM, bank, PRGRAMEnd-PRGRAMStart+1, address)
+}
+
+func (m *mmc1) chrAddress(address uint16) int {
+	m.registers.PPUA12 = address&0x1000 != 0x0000
+
+	if m.registers.Control&mmc1ControlCHR4K == 0x00 {
+		// 8 kiB, the low bit is ignored
+		return bankAddress(m.board.CHR, int(m.registers.CHR0>>1), chrBankSize, address)
+	}
+
+	return bankAddress(m.board.CHR, int(m.chrRegister()), chrBankSize/2, address)
+}
+
+func (m *mmc1) ReadPPU(address uint16) uint8 {
+	return m.board.CHR[m.chrAddress(address)]
+}
+
+func (m *mmc1) WritePPU(address uint16, value uint8) {
+	chrAddress := m.chrAddress(address)
+
+	if m.board.CHRRAM {
+		m.board.CHR[chrAddress] = value
+	}
+}
+
+func (m *mmc1) Mirroring() Mirroring {
+	switch m.registers.Control & 0x03 {
+	case 0x00:
+		return MirroringSingleScreenLower
+	case 0x01:
+		return MirroringSingleScreenUpper
+	case 0x02:
+		return MirroringVertical
+	default:
+		return MirroringHorizontal
+	}
+}
+
+func (m *mmc1) SaveState(w io.Writer) error {
+	if err := binary.Write(w, binary.LittleEndian, m.registers); err != nil {
+		return err
+	}
+
+	return m.baseMapper.SaveState(w)
+}
+
+func (m *mmc1) LoadState(r io.Reader) error {
+	if err := binary.Read(r, binary.LittleEndian, &m.registers); err != nil {
+		return err
+	}
+
+	return m.baseMapper.LoadState(r)
+}
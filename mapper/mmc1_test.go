@@ -0,0 +1,198 @@
+package mapper
+
+import "testing"
+
+func newTestMMC1(t *testing.T, board *Board) *mmc1 {
+	m, err := New(1, board)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m.(*mmc1)
+}
+
+// writeMMC1 writes value to the MMC1 register in address through the serial
+// port, as if each bit was written by a different instruction.
+func writeMMC1(m *mmc1, address uint16, value uint8) {
+	for i := 0; i < 5; i++ {
+		m.WriteCPU(address, value>>uint(i)&0x01)
+		m.ClockCPU()
+		m.ClockCPU()
+	}
+}
+
+func TestMMC1_PRGModes(t *testing.T) {
+	m := newTestMMC1(t, newTestBoard(0x40000, 0x4000, 0x2000, 0x1000))
+
+	tests := []struct {
+		control uint8
+		prg     uint8
+		low     uint8
+		high    uint8
+	}{
+		// last bank fixed in $C000 (power on)
+		{0x0C, 0x03, 0x03, 0x0F},
+		// first bank fixed in $8000
+		{0x08, 0x05, 0x00, 0x05},
+		// 32 kiB, the low bit is ignored
+		{0x00, 0x05, 0x04, 0x05},
+		{0x04, 0x06, 0x06, 0x07},
+	}
+
+	for _, test := range tests {
+		writeMMC1(m, 0x8000, test.control)
+		writeMMC1(m, 0xE000, test.prg)
+
+		if value, _ := m.ReadCPU(0x8000); value != test.low {
+			t.Errorf("unexpected bank in $8000 with control %02X; got=%v, want=%v", test.control, value, test.low)
+		}
+		if value, _ := m.ReadCPU(0xFFFF); value != test.high {
+			t.Errorf("unexpected bank in $C000 with control %02X; got=%v, want=%v", test.control, value, test.high)
+		}
+	}
+}
+
+func TestMMC1_CHRModes(t *testing.T) {
+	m := newTestMMC1(t, newTestBoard(0x8000, 0x4000, 0x20000, 0x1000))
+
+	writeMMC1(m, 0xA000, 0x05)
+	writeMMC1(m, 0xC000, 0x0A)
+
+	// 8 kiB, the low bit is ignored
+	if value := m.ReadPPU(0x0000); value != 0x04 {
+		t.Errorf("unexpected CHR bank in $0000 in 8 kiB mode; got=%v, want=%v", value, 0x04)
+	}
+	if value := m.ReadPPU(0x1000); value != 0x05 {
+		t.Errorf("unexpected CHR bank in $1000 in 8 kiB mode; got=%v, want=%v", value, 0x05)
+	}
+
+	writeMMC1(m, 0x8000, mmc1ControlCHR4K)
+
+	if value := m.ReadPPU(0x0000); value != 0x05 {
+		t.Errorf("unexpected CHR bank in $0000 in 4 kiB mode; got=%v, want=%v", value, 0x05)
+	}
+	if value := m.ReadPPU(0x1000); value != 0x0A {
+		t.Errorf("unexpected CHR bank in $1000 in 4 kiB mode; got=%v, want=%v", value, 0x0A)
+	}
+}
+
+func TestMMC1_Mirroring(t *testing.T) {
+	m := newTestMMC1(t, newTestBoard(0x8000, 0x4000, 0x2000, 0x1000))
+
+	for control, want := range []Mirroring{MirroringSingleScreenLower, MirroringSingleScreenUpper, MirroringVertical, MirroringHorizontal} {
+		writeMMC1(m, 0x9FFF, uint8(control))
+
+		if mirroring := m.Mirroring(); mirroring != want {
+			t.Errorf("unexpected mirroring with control %02X; got=%v, want=%v", control, mirroring, want)
+		}
+	}
+}
+
+func TestMMC1_Reset(t *testing.T) {
+	m := newTestMMC1(t, newTestBoard(0x40000, 0x4000, 0x2000, 0x1000))
+
+	writeMMC1(m, 0x8000, 0x08)
+
+	// the bits already written are discarded and the last bank is fixed
+	m.WriteCPU(0xE000, 0x01)
+	m.ClockCPU()
+	m.ClockCPU()
+	m.WriteCPU(0x8000, 0x80)
+	m.ClockCPU()
+	m.ClockCPU()
+	writeMMC1(m, 0xE000, 0x02)
+
+	if value, _ := m.ReadCPU(0x8000); value != 0x02 {
+		t.Errorf("unexpected bank in $8000 after reset; got=%v, want=%v", value, 0x02)
+	}
+	if value, _ := m.ReadCPU(0xC000); value != 0x0F {
+		t.Errorf("unexpected bank in $C000 after reset; got=%v, want=%v", value, 0x0F)
+	}
+}
+
+func TestMMC1_ConsecutiveWrites(t *testing.T) {
+	m := newTestMMC1(t, newTestBoard(0x40000, 0x4000, 0x2000, 0x1000))
+
+	// a read-modify-write operation writes the old value and then the new
+	// one in consecutive cycles: only the first write counts
+	for i := 0; i < 5; i++ {
+		m.ClockCPU()
+		m.WriteCPU(0xE000, 0x01)
+		m.ClockCPU()
+		m.WriteCPU(0xE000, 0x00)
+		m.ClockCPU()
+	}
+
+	if value, _ := m.ReadCPU(0x8000); value != 0x1F&0x0F {
+		t.Errorf("unexpected bank in $8000 after consecutive writes; got=%v, want=%v", value, 0x1F&0x0F)
+	}
+}
+
+func TestMMC1_PRGRAM(t *testing.T) {
+	m := newTestMMC1(t, newTestBoard(0x8000, 0x4000, 0x2000, 0x1000))
+
+	m.WriteCPU(0x6000, 0x12)
+
+	if value, ok := m.ReadCPU(0x6000); !ok || value != 0x12 {
+		t.Errorf("unexpected value read from the PRG RAM; got=%02X, want=%02X", value, 0x12)
+	}
+
+	writeMMC1(m, 0xE000, mmc1PRGRAMDisable)
+	m.WriteCPU(0x6000, 0x34)
+
+	if _, ok := m.ReadCPU(0x6000); ok {
+		t.Error("the PRG RAM should not be read when it's disabled")
+	}
+
+	writeMMC1(m, 0xE000, 0x00)
+
+	if value, _ := m.ReadCPU(0x6000); value != 0x12 {
+		t.Errorf("the PRG RAM should not be written when it's disabled; got=%02X, want=%02X", value, 0x12)
+	}
+}
+
+func TestMMC1_SUROM(t *testing.T) {
+	board := newTestBoard(0x80000, 0x4000, 0x2000, 0x1000)
+	board.CHRRAM = true
+	board.Submapper = mmc1SubmapperSUROM
+
+	m := newTestMMC1(t, board)
+
+	writeMMC1(m, 0xE000, 0x01)
+
+	if value, _ := m.ReadCPU(0x8000); value != 0x01 {
+		t.Errorf("unexpected bank in $8000 in the first 256 kiB; got=%v, want=%v", value, 0x01)
+	}
+	if value, _ := m.ReadCPU(0xC000); value != 0x0F {
+		t.Errorf("unexpected bank in $C000 in the first 256 kiB; got=%v, want=%v", value, 0x0F)
+	}
+
+	writeMMC1(m, 0xA000, 0x10)
+
+	if value, _ := m.ReadCPU(0x8000); value != 0x11 {
+		t.Errorf("unexpected bank in $8000 in the second 256 kiB; got=%v, want=%v", value, 0x11)
+	}
+	if value, _ := m.ReadCPU(0xC000); value != 0x1F {
+		t.Errorf("unexpected bank in $C000 in the second 256 kiB; got=%v, want=%v", value, 0x1F)
+	}
+}
+
+func TestMMC1_SXROM(t *testing.T) {
+	board := newTestBoard(0x80000, 0x4000, 0x2000, 0x1000)
+	board.CHRRAM = true
+	board.PRGRAM = make([]uint8, 0x8000)
+
+	// detected by the PRG RAM size
+	m := newTestMMC1(t, board)
+
+	for bank := uint8(0); bank < 4; bank++ {
+		writeMMC1(m, 0xA000, bank<<2)
+		m.WriteCPU(0x6000, bank+1)
+	}
+
+	for bank := 0; bank < 4; bank++ {
+		if value := board.PRGRAM[bank*0x2000]; value != uint8(bank+1) {
+			t.Errorf("unexpected value written to the PRG RAM bank %v; got=%v, want=%v", bank, value, bank+1)
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package mapper
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// MMC3 submappers (NES 2.0)
+const (
+	// MMC3C, by Sharp
+	mmc3SubmapperSharp = 0
+	// MMC3A, by NEC
+	mmc3SubmapperNEC = 4
+)
+
+const (
+	mmc3BankSelectPRGMode      = 0x40
+	mmc3BankSelectCHRInversion = 0x80
+
+	mmc3PRGRAMWriteProtect = 0x40
+	mmc3PRGRAMEnable       = 0x80
+
+	mmc3PRGBankSize = 0x2000
+	mmc3CHRBankSize = 0x0400
+
+	// number of CPU cycles PPU A12 must stay low before a rising edge
+	// clocks the IRQ counter; shorter pulses are filtered out
+	mmc3A12LowCycles = 3
+)
+
+// MMC3 (mapper 4), used by the TxROM boards, switches 8 kiB PRG banks and
+// 1 or 2 kiB CHR banks through 8 bank registers. It has a counter which is
+// clocked by the rising edges of PPU A12, which happen once per scanline
+// when the background and the sprites use different pattern tables, and
+// asserts /IRQ when it reaches 0.
+type mmc3 struct {
+	baseMapper
+
+	registers mmc3Registers
+	nec       bool
+}
+
+// mmc3Registers holds the state of MMC3 saved by SaveState.
+type mmc3Registers struct {
+	BankSelect uint8
+	Banks      [8]uint8
+	Mirroring  uint8
+	PRGRAM     uint8
+
+	IRQLatch   uint8
+	IRQCounter uint8
+	IRQReload  bool
+	IRQEnabled bool
+	IRQ        bool
+
+	PPUA12 bool
+	// CPU cycles since PPU A12 went low
+	A12LowCycles uint8
+}
+
+func init() {
+	Register(4, "MMC3", newMMC3)
+}
+
+func newMMC3(board *Board) (Mapper, error) {
+	if size := len(board.PRG); size == 0 || size%mmc3PRGBankSize != 0 {
+		return nil, InvalidMemorySizeError{"MMC3", "PRG ROM", size}
+	}
+
+	return &mmc3{
+		baseMapper: baseMapper{board},
+		registers: mmc3Registers{
+			PRGRAM: mmc3PRGRAMEnable,
+		},
+		nec: board.Submapper == mmc3SubmapperNEC,
+	}, nil
+}
+
+// ClockCPU counts for how long PPU A12 has been low.
+func (m *mmc3) ClockCPU() {
+	if !m.registers.PPUA12 && m.registers.A12LowCycles < mmc3A12LowCycles {
+		m.registers.A12LowCycles++
+	}
+}
+
+func (m *mmc3) ReadCPU(address uint16) (uint8, bool) {
+	switch {
+	case address >= PRGROMStart:
+		return readBank(m.board.PRG, m.prgBank(address), mmc3PRGBankSize, address), true
+	case address >= PRGRAMStart:
+		if m.registers.PRGRAM&mmc3PRGRAMEnable == 0x00 {
+			return 0x00, false
+		}
+
+		return m.readPRGRAM(address)
+	default:
+		return 0x00, false
+	}
+}
+
+func (m *mmc3) WriteCPU(address uint16, value uint8) {
+	r := &m.registers
+
+	if address >= PRGRAMStart && address <= PRGRAMEnd {
+		if r.PRGRAM&mmc3PRGRAMEnable != 0x00 && r.PRGRAM&mmc3PRGRAMWriteProtect == 0x00 {
+			m.writePRGRAM(address, value)
+		}
+
+		return
+	}
+
+	if address < PRGROMStart {
+		return
+	}
+
+	// the registers are selected by the address range and by A0
+	even := address&0x0001 == 0x0000
+
+	switch address & 0xE000 {
+	case 0x8000:
+		if even {
+			r.BankSelect = value
+		} else {
+			r.Banks[r.BankSelect&0x07] = value
+		}
+	case 0xA000:
+		if even {
+			r.Mirroring = value & 0x01
+		} else {
+			r.PRGRAM = value
+		}
+	case 0xC000:
+		if even {
+			r.IRQLatch = value
+		} else {
+			r.IRQCounter = 0
+			r.IRQReload = true
+		}
+	case 0xE000:
+		if even {
+			r.IRQEnabled = false
+			r.IRQ = false
+		} else {
+			r.IRQEnabled = true
+		}
+	}
+}
+
+// prgBank returns the 8 kiB bank mapped in address.
+func (m *mmc3) prgBank(address uint16) int {
+	count := bankCount(m.board.PRG, mmc3PRGBankSize)
+	// the fixed banks wrap around when there are fewer than 2 banks
+	secondLast := (count - 2 + count) % count
+	swap := m.registers.BankSelect&mmc3BankSelectPRGMode != 0x00
+
+	switch address & 0xE000 {
+	case 0x8000:
+		if swap {
+			return secondLast
+		}
+
+		return int(m.registers.Banks[6] & 0x3F)
+	case 0xA000:
+		return int(m.registers.Banks[7] & 0x3F)
+	case 0xC000:
+		if swap {
+			return int(m.registers.Banks[6] & 0x3F)
+		}
+
+		return secondLast
+	default:
+		return count - 1
+	}
+}
+
+// chrBank returns the 1 kiB bank mapped in address.
+func (m *mmc3) chrBank(address uint16) int {
+	if m.registers.BankSelect&mmc3BankSelectCHRInversion != 0x00 {
+		address ^= 0x1000
+	}
+
+	slot := address >> 10 & 0x07
+
+	if slot < 4 {
+		// R0 and R1 select 2 kiB banks, so their low bit is ignored
+		return int(m.registers.Banks[slot>>1]&0xFE) | int(slot&0x01)
+	}
+
+	return int(m.registers.Banks[slot-2])
+}
+
+// watchA12 clocks the IRQ counter when the PPU A12 rises after being low
+// for long enough.
+func (m *mmc3) watchA12(address uint16) {
+	r := &m.registers
+	a12 := address&0x1000 != 0x0000
+
+	if a12 && !r.PPUA12 && r.A12LowCycles >= mmc3A12LowCycles {
+		m.clockIRQCounter()
+	}
+
+	if a12 != r.PPUA12 {
+		r.A12LowCycles = 0
+	}
+
+	r.PPUA12 = a12
+}
+
+// clockIRQCounter reloads the IRQ counter when it's 0 (or when a reload was
+// requested), or decrements it otherwise. The IRQ is asserted when the
+// counter ends up as 0. The Sharp MMC3 does it every time, so a latch of 0
+// asserts the IRQ on every clock; the NEC MMC3 only does it when the
+// counter was decremented or reloaded by a request.
+func (m *mmc3) clockIRQCounter() {
+	r := &m.registers
+	previous := r.IRQCounter
+	reload := r.IRQReload
+
+	if r.IRQCounter == 0 || r.IRQReload {
+		r.IRQCounter = r.IRQLatch
+		r.IRQReload = false
+	} else {
+		r.IRQCounter--
+	}
+
+	if r.IRQCounter == 0 && r.IRQEnabled && (!m.nec || previous != 0 || reload) {
+		r.IRQ = true
+	}
+}
+
+func (m *mmc3) ReadPPU(address uint16) uint8 {
+	m.watchA12(address)
+
+	return readBank(m.board.CHR, m.chrBank(address), mmc3CHRBankSize, address)
+}
+
+func (m *mmc3) WritePPU(address uint16, value uint8) {
+	m.watchA12(address)
+
+	if m.board.CHRRAM {
+		writeBank(m.board.CHR, m.chrBank(address), mmc3CHRBankSize, address, value)
+	}
+}
+
+func (m *mmc3) Mirroring() Mirroring {
+	if m.board.Mirroring == MirroringFourScreen {
+		return MirroringFourScreen
+	}
+
+	if m.registers.Mirroring == 0x00 {
+		return MirroringVertical
+	}
+
+	return MirroringHorizontal
+}
+
+func (m *mmc3) IRQ() bool {
+	return m.registers.IRQ
+}
+
+func (m *mmc3) SaveState(w io.Writer) error {
+	if err := binary.Write(w, binary.LittleEndian, m.registers); err != nil {
+		return err
+	}
+
+	return m.baseMapper.SaveState(w)
+}
+
+func (m *mmc3) LoadState(r io.Reader) error {
+	if err := binary.Read(r, binary.LittleEndian, &m.registers); err != nil {
+		return err
+	}
+
+	return m.baseMapper.LoadState(r)
+}
@@ -0,0 +1,229 @@
+package mapper
+
+import "testing"
+
+func newTestMMC3(t *testing.T, board *Board) *mmc3 {
+	m, err := New(4, board)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return m.(*mmc3)
+}
+
+// scanline makes the PPU fetch the background from $0000 and then the
+// sprites from $1000, which clocks the IRQ counter once.
+func scanline(m *mmc3) {
+	m.ReadPPU(0x0000)
+
+	for i := 0; i < 85; i++ {
+		m.ClockCPU()
+	}
+
+	m.ReadPPU(0x1000)
+
+	for i := 0; i < 28; i++ {
+		m.ClockCPU()
+	}
+}
+
+func TestMMC3_PRGModes(t *testing.T) {
+	m := newTestMMC3(t, newTestBoard(0x20000, 0x2000, 0x2000, 0x0400))
+
+	m.WriteCPU(0x8000, 0x06)
+	m.WriteCPU(0x8001, 0x03)
+	m.WriteCPU(0x8000, 0x07)
+	m.WriteCPU(0x8001, 0x05)
+
+	tests := []struct {
+		bankSelect uint8
+		want       [4]uint8
+	}{
+		{0x07, [4]uint8{0x03, 0x05, 0x0E, 0x0F}},
+		{0x47, [4]uint8{0x0E, 0x05, 0x03, 0x0F}},
+	}
+
+	for _, test := range tests {
+		m.WriteCPU(0x8000, test.bankSelect)
+
+		for i, want := range test.want {
+			address := uint16(0x8000 + i*0x2000)
+
+			if value, _ := m.ReadCPU(address); value != want {
+				t.Errorf("unexpected bank in $%04X with bank select %02X; got=%v, want=%v", address, test.bankSelect, value, want)
+			}
+		}
+	}
+}
+
+func TestMMC3_SmallPRG(t *testing.T) {
+	// a single 8 kiB bank is mapped in every window
+	board := newTestBoard(0x2000, 0x2000, 0x2000, 0x0400)
+	board.PRG[0x0001] = 0xAA
+	m := newTestMMC3(t, board)
+
+	for _, bankSelect := range []uint8{0x00, 0x40} {
+		m.WriteCPU(0x8000, bankSelect)
+
+		for address := uint32(0x8001); address <= 0xFFFF; address += 0x2000 {
+			if value, _ := m.ReadCPU(uint16(address)); value != 0xAA {
+				t.Errorf("unexpected value in $%04X with bank select %02X; got=%02X, want=AA", address, bankSelect, value)
+			}
+		}
+	}
+}
+
+func TestMMC3_CHRModes(t *testing.T) {
+	m := newTestMMC3(t, newTestBoard(0x8000, 0x2000, 0x10000, 0x0400))
+
+	for r, bank := range []uint8{0x11, 0x14, 0x20, 0x21, 0x22, 0x23} {
+		m.WriteCPU(0x8000, uint8(r))
+		m.WriteCPU(0x8001, bank)
+	}
+
+	tests := []struct {
+		bankSelect uint8
+		want       [8]uint8
+	}{
+		// the low bit of R0 and R1 is ignored
+		{0x00, [8]uint8{0x10, 0x11, 0x14, 0x15, 0x20, 0x21, 0x22, 0x23}},
+		{0x80, [8]uint8{0x20, 0x21, 0x22, 0x23, 0x10, 0x11, 0x14, 0x15}},
+	}
+
+	for _, test := range tests {
+		m.WriteCPU(0x8000, test.bankSelect)
+
+		for i, want := range test.want {
+			address := uint16(i * 0x0400)
+
+			if value := m.ReadPPU(address); value != want {
+				t.Errorf("unexpected CHR bank in $%04X with bank select %02X; got=%v, want=%v", address, test.bankSelect, value, want)
+			}
+		}
+	}
+}
+
+func TestMMC3_Mirroring(t *testing.T) {
+	board := newTestBoard(0x8000, 0x2000, 0x2000, 0x0400)
+	m := newTestMMC3(t, board)
+
+	m.WriteCPU(0xA000, 0x01)
+
+	if mirroring := m.Mirroring(); mirroring != MirroringHorizontal {
+		t.Errorf("unexpected mirroring; got=%v, want=%v", mirroring, MirroringHorizontal)
+	}
+
+	m.WriteCPU(0xBFFE, 0x00)
+
+	if mirroring := m.Mirroring(); mirroring != MirroringVertical {
+		t.Errorf("unexpected mirroring; got=%v, want=%v", mirroring, MirroringVertical)
+	}
+
+	board.Mirroring = MirroringFourScreen
+
+	if mirroring := m.Mirroring(); mirroring != MirroringFourScreen {
+		t.Errorf("the mirroring should not be changed in a four screen board; got=%v, want=%v", mirroring, MirroringFourScreen)
+	}
+}
+
+func TestMMC3_PRGRAMProtect(t *testing.T) {
+	m := newTestMMC3(t, newTestBoard(0x8000, 0x2000, 0x2000, 0x0400))
+
+	m.WriteCPU(0x6000, 0x12)
+	m.WriteCPU(0xA001, mmc3PRGRAMEnable|mmc3PRGRAMWriteProtect)
+	m.WriteCPU(0x6000, 0x34)
+
+	if value, _ := m.ReadCPU(0x6000); value != 0x12 {
+		t.Errorf("the PRG RAM should not be written when it's protected; got=%02X, want=%02X", value, 0x12)
+	}
+
+	m.WriteCPU(0xA001, 0x00)
+
+	if _, ok := m.ReadCPU(0x6000); ok {
+		t.Error("the PRG RAM should not be read when it's disabled")
+	}
+}
+
+func TestMMC3_IRQ(t *testing.T) {
+	m := newTestMMC3(t, newTestBoard(0x8000, 0x2000, 0x2000, 0x0400))
+
+	m.WriteCPU(0xC000, 3)
+	m.WriteCPU(0xC001, 0x00)
+	m.WriteCPU(0xE001, 0x00)
+
+	// reload to 3, then 2, 1 and 0
+	for i := 0; i < 3; i++ {
+		scanline(m)
+
+		if m.IRQ() {
+			t.Fatalf("IRQ should not be asserted after %v scanline(s)", i+1)
+		}
+	}
+
+	scanline(m)
+
+	if !m.IRQ() {
+		t.Fatal("IRQ should be asserted when the counter reaches 0")
+	}
+
+	m.WriteCPU(0xE000, 0x00)
+
+	if m.IRQ() {
+		t.Error("IRQ should be acknowledged when it's disabled")
+	}
+}
+
+func TestMMC3_IRQFilter(t *testing.T) {
+	m := newTestMMC3(t, newTestBoard(0x8000, 0x2000, 0x2000, 0x0400))
+
+	m.WriteCPU(0xC000, 1)
+	m.WriteCPU(0xC001, 0x00)
+	m.WriteCPU(0xE001, 0x00)
+
+	scanline(m)
+
+	// A12 toggles too fast to be seen by the MMC3
+	for i := 0; i < 8; i++ {
+		m.ReadPPU(0x0000)
+		m.ReadPPU(0x1000)
+	}
+
+	if m.IRQ() {
+		t.Error("short A12 pulses should not clock the IRQ counter")
+	}
+}
+
+func TestMMC3_IRQLatchZero(t *testing.T) {
+	tests := []struct {
+		submapper uint8
+		want      []bool
+	}{
+		// asserted on every scanline
+		{mmc3SubmapperSharp, []bool{true, true, true}},
+		// only asserted after the reload request
+		{mmc3SubmapperNEC, []bool{true, false, false}},
+	}
+
+	for _, test := range tests {
+		board := newTestBoard(0x8000, 0x2000, 0x2000, 0x0400)
+		board.Submapper = test.submapper
+
+		m := newTestMMC3(t, board)
+
+		m.WriteCPU(0xC000, 0)
+		m.WriteCPU(0xC001, 0x00)
+		m.WriteCPU(0xE001, 0x00)
+
+		for i, want := range test.want {
+			scanline(m)
+
+			if irq := m.IRQ(); irq != want {
+				t.Errorf("unexpected IRQ in scanline %v with submapper %v; got=%v, want=%v", i, test.submapper, irq, want)
+			}
+
+			// acknowledge
+			m.WriteCPU(0xE000, 0x00)
+			m.WriteCPU(0xE001, 0x00)
+		}
+	}
+}
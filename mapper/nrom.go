@@ -0,0 +1,40 @@
+package mapper
+
+// NROM (mapper 0) has no bank switching: 16 or 32 kiB of PRG ROM and 8 kiB of
+// CHR. A 16 kiB PRG ROM is mirrored in $8000-$BFFF and $C000-$FFFF.
+type nrom struct {
+	baseMapper
+}
+
+func init() {
+	Register(0, "NROM", newNROM)
+}
+
+func newNROM(board *Board) (Mapper, error) {
+	if size := len(board.PRG); size != prgBankSize && size != 2*prgBankSize {
+		return nil, InvalidMemorySizeError{"NROM", "PRG ROM", size}
+	}
+
+	if size := len(board.CHR); size != chrBankSize {
+		return nil, InvalidMemorySizeError{"NROM", "CHR", size}
+	}
+
+	return &nrom{baseMapper{board}}, nil
+}
+
+func (m *nrom) ReadCPU(address uint16) (uint8, bool) {
+	switch {
+	case address >= PRGROMStart:
+		return readBank(m.board.PRG, 0, len(m.board.PRG), address-PRGROMStart), true
+	case address >= PRGRAMStart:
+		return m.readPRGRAM(address)
+	default:
+		return 0x00, false
+	}
+}
+
+func (m *nrom) WriteCPU(address uint16, value uint8) {
+	if address >= PRGRAMStart && address <= PRGRAMEnd {
+		m.writePRGRAM(address, value)
+	}
+}
@@ -0,0 +1,46 @@
+package mapper
+
+import "fmt"
+
+// Constructor creates a mapper connected to board.
+type Constructor func(board *Board) (Mapper, error)
+
+type registration struct {
+	name        string
+	constructor Constructor
+}
+
+// mappers registered, by their iNES number
+var registry = make(map[uint16]registration)
+
+// Register makes a mapper available with its iNES number. It's meant to be
+// called from init functions, so it panics if the number is registered
+// twice.
+func Register(number uint16, name string, constructor Constructor) {
+	if r, found := registry[number]; found {
+		panic(fmt.Sprintf("mapper %v is already registered as %v", number, r.name))
+	}
+
+	registry[number] = registration{name, constructor}
+}
+
+// New creates the mapper with the iNES number connected to board.
+func New(number uint16, board *Board) (Mapper, error) {
+	r, found := registry[number]
+	if !found {
+		return nil, UnsupportedMapperError{number}
+	}
+
+	return r.constructor(board)
+}
+
+// Name returns the name of the mapper with the iNES number, or an empty
+// string if it's not supported.
+func Name(number uint16) string {
+	return registry[number].name
+}
+
+func IsSupported(number uint16) bool {
+	_, found := registry[number]
+	return found
+}
@@ -0,0 +1,38 @@
+package mapper
+
+// UxROM (mapper 2) switches the 16 kiB PRG bank in $8000-$BFFF; the last bank
+// is fixed in $C000-$FFFF.
+type uxrom struct {
+	discreteMapper
+}
+
+func init() {
+	Register(2, "UxROM", newUxROM)
+}
+
+func newUxROM(board *Board) (Mapper, error) {
+	base, err := newDiscreteMapper("UxROM", board, prgBankSize, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return &uxrom{base}, nil
+}
+
+func (m *uxrom) ReadCPU(address uint16) (uint8, bool) {
+	if address < PRGROMStart {
+		return m.readCPU(address, 0)
+	}
+
+	bank := int(m.latch)
+	if address >= 0xC000 {
+		bank = bankCount(m.board.PRG, prgBankSize) - 1
+	}
+
+	return readBank(m.board.PRG, bank, prgBankSize, address), true
+}
+
+func (m *uxrom) WriteCPU(address uint16, value uint8) {
+	rom, _ := m.ReadCPU(address)
+	m.writeCPU(address, value, rom)
+}
@@ -13,7 +13,7 @@ func (m Memory) ReadByte(address uint16) uint8 {
 }
 
 func (m Memory) ReadWord(address uint16) uint16 {
-	return util.JoinBytesInWord(m[address : address+2])
+	return util.JoinBytesInWord([]uint8{m[address], m[address+1]})
 }
 
 func (m Memory) ReadWordSamePage(address uint16) uint16 {
@@ -28,5 +28,8 @@ func (m Memory) WriteByte(address uint16, value uint8) {
 }
 
 func (m Memory) WriteWord(address uint16, value uint16) {
-	copy(m[address:address+2], util.BreakWordIntoBytes(value))
+	bytes := util.BreakWordIntoBytes(value)
+
+	m[address] = bytes[0]
+	m[address+1] = bytes[1]
 }
@@ -0,0 +1,153 @@
+package nes
+
+import (
+	"github.com/cd1/nes-emulator/mapper"
+	"github.com/cd1/nes-emulator/ppu"
+)
+
+// CPU memory map
+const (
+	RAMStart = 0x0000
+	RAMEnd   = 0x1FFF
+	RAMSize  = 0x0800
+
+	PPURegistersStart = 0x2000
+	PPURegistersEnd   = 0x3FFF
+	PPURegistersSize  = 0x0008
+
+	APURegistersStart = 0x4000
+	APURegistersEnd   = 0x401F
+	APURegistersSize  = 0x0020
+
+	OAMDMAAddress      = 0x4014
+	APUStatusAddress   = 0x4015
+	Controller1Address = 0x4016
+	Controller2Address = 0x4017
+
+	CartridgeStart = mapper.CartridgeStart
+	CartridgeEnd   = mapper.CartridgeEnd
+
+	PRGRAMStart = mapper.PRGRAMStart
+	PRGRAMEnd   = mapper.PRGRAMEnd
+	PRGRAMSize  = 0x2000
+
+	// the trainer is loaded into the PRG RAM
+	TrainerStart = 0x7000
+	TrainerEnd   = TrainerStart + TrainerSize - 1
+
+	PRGROMStart = mapper.PRGROMStart
+	PRGROMEnd   = mapper.PRGROMEnd
+)
+
+// PPU memory map
+const (
+	PatternTablesStart = ppu.PatternTablesStart
+	PatternTablesEnd   = ppu.PatternTablesEnd
+
+	// 4 nametables of 1 kiB, mirrored up to $3EFF
+	NametablesStart = ppu.NametablesStart
+	NametablesEnd   = ppu.NametablesEnd
+	NametablesSize  = 0x1000
+	NametableSize   = 0x0400
+
+	// RAM for 2 nametables
+	CIRAMSize = 0x0800
+)
+
+// connectDevices registers in bus the NES components, with cart inserted.
+// The APU registers aren't emulated yet, so they work as plain memory. Only
+// the APU status and the controller ports can be read from the APU and I/O
+// registers, the others are write-only. A write to OAMDMAAddress calls
+// oamDMA with the page written.
+func connectDevices(bus Bus, cart *Cartridge, ppu *ppu.PPU, oamDMA func(page uint8)) error {
+	ram := NewMemory(RAMSize)
+	apuRegisters := NewMemory(APURegistersSize)
+
+	devices := []Device{
+		{Name: "RAM", Start: RAMStart, End: RAMEnd, Mask: RAMSize - 1, Read: ram.ReadByte, Write: ram.WriteByte},
+		{Name: "PPU registers", Start: PPURegistersStart, End: PPURegistersEnd, Mask: PPURegistersSize - 1, Read: ppu.ReadRegister, Write: ppu.WriteRegister, Peek: ppu.PeekRegister},
+		{Name: "APU and I/O registers", Start: APURegistersStart, End: APURegistersEnd, Mask: APURegistersSize - 1, Write: apuRegisters.WriteByte},
+		{Name: "OAM DMA", Start: OAMDMAAddress, End: OAMDMAAddress, Write: func(_ uint16, page uint8) { oamDMA(page) }},
+		// bit 5 isn't connected
+		{Name: "APU status", Start: APUStatusAddress, End: APUStatusAddress, Undriven: 0x20, Read: apuRegisters[APUStatusAddress-APURegistersStart:].ReadByte, Write: apuRegisters[APUStatusAddress-APURegistersStart:].WriteByte},
+		// only the low bits are driven by the controllers
+		{Name: "controller ports", Start: Controller1Address, End: Controller2Address, Mask: 0x0001, Undriven: 0xE0, Read: apuRegisters[Controller1Address-APURegistersStart:].ReadByte, Write: apuRegisters[Controller1Address-APURegistersStart:].WriteByte},
+		cart.device(bus),
+	}
+
+	for _, device := range devices {
+		if err := bus.Register(device); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ppuBus connects the PPU to the pattern tables in the cartridge and to the
+// nametables, which are in the CIRAM (2 kiB) inside the NES. The cartridge
+// chooses how the 4 nametables are mirrored over the CIRAM, and a four-screen
+// board has the RAM for the other 2 nametables.
+type ppuBus struct {
+	cart  *Cartridge
+	ciram Memory
+}
+
+func newPPUBus(cart *Cartridge) *ppuBus {
+	return &ppuBus{
+		cart:  cart,
+		ciram: NewMemory(CIRAMSize),
+	}
+}
+
+// nametable returns the memory and the offset in it of address, in
+// $2000-$3EFF, according to the current mirroring of the cartridge.
+func (bus *ppuBus) nametable(address uint16) (Memory, uint16) {
+	// $3000-$3EFF mirrors $2000-$2EFF
+	offset := (address - NametablesStart) % NametablesSize
+	table := offset / NametableSize
+	offset %= NametableSize
+
+	switch bus.cart.Mapper.Mirroring() {
+	case mapper.MirroringHorizontal:
+		table >>= 1
+	case mapper.MirroringVertical:
+		table &= 0x01
+	case mapper.MirroringSingleScreenLower:
+		table = 0
+	case mapper.MirroringSingleScreenUpper:
+		table = 1
+	case mapper.MirroringFourScreen:
+		if table >= 2 && len(bus.cart.vram) > 0 {
+			return bus.cart.vram, (table-2)*NametableSize + offset
+		}
+
+		table &= 0x01
+	}
+
+	return bus.ciram, table*NametableSize + offset
+}
+
+func (bus *ppuBus) ReadByte(address uint16) uint8 {
+	address &= ppu.AddressMask
+
+	if address <= PatternTablesEnd {
+		return bus.cart.ReadPPU(address)
+	}
+
+	memory, offset := bus.nametable(address)
+
+	return memory.ReadByte(offset)
+}
+
+func (bus *ppuBus) WriteByte(address uint16, value uint8) {
+	address &= ppu.AddressMask
+
+	if address <= PatternTablesEnd {
+		bus.cart.WritePPU(address, value)
+		return
+	}
+
+	memory, offset := bus.nametable(address)
+	memory.WriteByte(offset, value)
+}
@@ -0,0 +1,325 @@
+package nes
+
+import (
+	"testing"
+
+	"github.com/cd1/nes-emulator/mapper"
+)
+
+func newMemoryMapTestNES(t *testing.T, prgBankCount uint8) *NES {
+	game := newTestGame(t, prgBankCount, 0)
+	for i := range game.PRG {
+		game.PRG[i] = uint8(i) ^ uint8(i>>8)
+	}
+
+	var system NES
+	if _, err := system.PowerOn(*game); err != nil {
+		t.Fatal(err)
+	}
+
+	return &system
+}
+
+// testMirrors checks that every address in start-end is written and read as
+// its canonical address, which is found by masking its offset from start.
+func testMirrors(t *testing.T, system *NES, start uint16, end uint16, mask uint16) {
+	for address := int(start); address <= int(end); address++ {
+		mirror := uint16(address)
+		canonical := start + (mirror-start)&mask
+		value := uint8(address) ^ uint8(address>>8) ^ 0x5A
+
+		system.WriteByte(mirror, value)
+
+		if got := system.ReadByte(canonical); got != value {
+			t.Fatalf("write to $%04X should be read from $%04X; got=%02X, want=%02X", mirror, canonical, got, value)
+		}
+		if got := system.ReadByte(mirror); got != value {
+			t.Fatalf("unexpected value read from $%04X; got=%02X, want=%02X", mirror, got, value)
+		}
+
+		system.WriteByte(canonical, ^value)
+
+		if got := system.ReadByte(mirror); got != ^value {
+			t.Fatalf("write to $%04X should be read from $%04X; got=%02X, want=%02X", canonical, mirror, got, ^value)
+		}
+	}
+}
+
+func TestMemoryMap_RAM(t *testing.T) {
+	// 0x0800-0x1FFF: mirrors of 0x0000-0x07FF
+	testMirrors(t, newMemoryMapTestNES(t, 1), RAMStart, RAMEnd, RAMSize-1)
+}
+
+func TestMemoryMap_PPURegisters(t *testing.T) {
+	system := newMemoryMapTestNES(t, 1)
+
+	// 0x2008-0x3FFF: mirrors of 0x2000-0x2007
+	for mirror := PPURegistersStart; mirror <= PPURegistersEnd; mirror += PPURegistersSize {
+		value := uint8(mirror >> 3)
+
+		// PPUADDR = $2345, then PPUDATA
+		system.WriteByte(uint16(mirror+6), 0x23)
+		system.WriteByte(uint16(mirror+6), 0x45)
+		system.WriteByte(uint16(mirror+7), value)
+
+		system.WriteByte(PPURegistersStart+6, 0x23)
+		system.WriteByte(PPURegistersStart+6, 0x45)
+		// the first read only fills the buffer
+		system.ReadByte(PPURegistersStart + 7)
+
+		if got := system.ReadByte(PPURegistersStart + 7); got != value {
+			t.Fatalf("write to PPUDATA through $%04X should be read from $2007; got=%02X, want=%02X", mirror+7, got, value)
+		}
+	}
+}
+
+func TestMemoryMap_APURegisters(t *testing.T) {
+	system := newMemoryMapTestNES(t, 1)
+
+	system.WriteByte(APUStatusAddress, 0x1F)
+	system.WriteByte(Controller1Address, 0x01)
+	system.WriteByte(Controller2Address, 0xFF)
+
+	// the data bus holds 0xAA before each read
+	system.WriteByte(0x0000, 0xAA)
+
+	for address := uint16(APURegistersStart); address <= APURegistersEnd; address++ {
+		var want uint8
+
+		switch address {
+		case APUStatusAddress:
+			want = 0x1F | 0xAA&0x20
+		case Controller1Address:
+			want = 0x01 | 0xAA&0xE0
+		case Controller2Address:
+			want = 0x1F | 0xAA&0xE0
+		default:
+			// write-only
+			want = 0xAA
+		}
+
+		if got := system.ReadByte(address); got != want {
+			t.Errorf("unexpected value read from $%04X; got=%02X, want=%02X", address, got, want)
+		}
+
+		system.ReadByte(0x0000)
+	}
+}
+
+func TestMemoryMap_PRGRAM(t *testing.T) {
+	// not mirrored
+	testMirrors(t, newMemoryMapTestNES(t, 1), PRGRAMStart, PRGRAMEnd, 0xFFFF)
+}
+
+func TestMemoryMap_PRGROM(t *testing.T) {
+	tests := []struct {
+		prgBankCount uint8
+		mask         uint16
+	}{
+		// 0xC000-0xFFFF: mirror of 0x8000-0xBFFF
+		{1, PRGBankSize - 1},
+		// not mirrored
+		{2, 2*PRGBankSize - 1},
+	}
+
+	for _, test := range tests {
+		system := newMemoryMapTestNES(t, test.prgBankCount)
+
+		for address := PRGROMStart; address <= PRGROMEnd; address++ {
+			offset := uint16(address-PRGROMStart) & test.mask
+			want := uint8(offset) ^ uint8(offset>>8)
+
+			system.WriteByte(uint16(address), ^want)
+
+			if got := system.ReadByte(uint16(address)); got != want {
+				t.Fatalf("unexpected value read from $%04X with %v PRG bank(s); got=%02X, want=%02X", address, test.prgBankCount, got, want)
+			}
+		}
+	}
+}
+
+func TestMemoryMap_Unmapped(t *testing.T) {
+	system := newMemoryMapTestNES(t, 1)
+
+	for address := uint16(APURegistersEnd + 1); address < PRGRAMStart; address++ {
+		value := uint8(address)
+
+		system.WriteByte(address, ^value)
+		system.WriteByte(0x0000, value)
+
+		if got := system.ReadByte(address); got != value {
+			t.Fatalf("unexpected value read from unmapped $%04X; got=%02X, want=%02X", address, got, value)
+		}
+	}
+}
+
+func TestMemoryMap_OpenBus(t *testing.T) {
+	tests := []struct {
+		name    string
+		program []uint8
+		want    uint8
+	}{
+		// the last value on the data bus is the high byte of the address
+		{"unmapped", []uint8{0xAD, 0x00, 0x50}, 0x50},
+		{"write-only register", []uint8{0xAD, 0x00, 0x40}, 0x40},
+		{"partially decoded register", []uint8{0xAD, 0x16, 0x40}, 0x40 | 0x03},
+		// the indirect address is read last
+		{"indexed indirect", []uint8{0xA1, 0x10}, 0x5F},
+	}
+
+	for _, test := range tests {
+		game := newTestGame(t, 1, 0)
+		copy(game.PRG, test.program)
+		// reset vector
+		game.PRG[0x3FFC] = 0x00
+		game.PRG[0x3FFD] = 0x80
+
+		var system NES
+		if _, err := system.PowerOn(*game); err != nil {
+			t.Fatal(err)
+		}
+
+		system.WriteByte(Controller1Address, 0x03)
+		system.WriteWord(0x0010, 0x5FFF)
+
+		if _, err := system.Step(); err != nil {
+			t.Fatal(err)
+		}
+
+		if a := system.CPU.Accumulator; a != test.want {
+			t.Errorf("unexpected value read from the open bus (%v); got=%02X, want=%02X", test.name, a, test.want)
+		}
+	}
+}
+
+func TestMemoryMap_Word(t *testing.T) {
+	system := newMemoryMapTestNES(t, 1)
+
+	// the high byte is written to 0x0800, a mirror of 0x0000
+	system.WriteWord(0x07FF, 0x1234)
+
+	if value := system.ReadByte(0x0000); value != 0x12 {
+		t.Errorf("unexpected high byte of the word written across the RAM mirror; got=%02X, want=%02X", value, 0x12)
+	}
+	if value := system.ReadWord(0x0FFF); value != 0x1234 {
+		t.Errorf("unexpected word read from the RAM mirror; got=%04X, want=%04X", value, 0x1234)
+	}
+
+	system.WriteByte(0x0700, 0x56)
+
+	// the high byte is read from 0x1700, which is a mirror of 0x0700
+	if value := system.ReadWordSamePage(0x17FF); value != 0x5634 {
+		t.Errorf("unexpected word read from the same page of the RAM mirror; got=%04X, want=%04X", value, 0x5634)
+	}
+
+	// the high byte is written to the PPU registers
+	system.WriteWord(0x1FFF, 0xABCD)
+
+	if value := system.ReadByte(0x07FF); value != 0xCD {
+		t.Errorf("unexpected low byte of the word written across the RAM end; got=%02X, want=%02X", value, 0xCD)
+	}
+	if value := system.ReadByte(0x3FF8); value != 0xAB {
+		t.Errorf("unexpected high byte of the word written across the RAM end; got=%02X, want=%02X", value, 0xAB)
+	}
+}
+
+func TestPPUMemoryMap_Nametables(t *testing.T) {
+	tests := []struct {
+		name  string
+		flag6 uint8
+		// CIRAM offset of each nametable
+		tables [4]uint16
+		// whether the nametables 2 and 3 are in the cartridge
+		cartridge bool
+	}{
+		{"horizontal", 0x00, [4]uint16{0x000, 0x000, 0x400, 0x400}, false},
+		{"vertical", 0x01, [4]uint16{0x000, 0x400, 0x000, 0x400}, false},
+		{"four screen", 0x08, [4]uint16{0x000, 0x400, 0x000, 0x400}, true},
+	}
+
+	for _, test := range tests {
+		game := newTestGame(t, 1, 1)
+		game.Header[6] = test.flag6
+
+		cart, err := NewCartridge(*game)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		bus := newPPUBus(cart)
+
+		for table, offset := range test.tables {
+			// $3000-$3EFF mirrors $2000-$2EFF
+			for _, start := range []uint16{NametablesStart, NametablesStart + NametablesSize} {
+				address := start + uint16(table)*NametableSize + 0x123
+				value := uint8(address>>8) ^ test.flag6
+
+				bus.WriteByte(address, value)
+
+				var got uint8
+				if test.cartridge && table >= 2 {
+					got = cart.vram[offset+0x123]
+				} else {
+					got = bus.ciram[offset+0x123]
+				}
+
+				if got != value {
+					t.Errorf("write to $%04X with %v mirroring should go to $%03X; got=%02X, want=%02X", address, test.name, offset+0x123, got, value)
+				}
+				if got := bus.ReadByte(address); got != value {
+					t.Errorf("unexpected value read from $%04X with %v mirroring; got=%02X, want=%02X", address, test.name, got, value)
+				}
+			}
+		}
+	}
+}
+
+func TestPPUMemoryMap_MapperMirroring(t *testing.T) {
+	game := newTestGame(t, 2, 1)
+	// MMC1, vertical mirroring in the header
+	game.Header[6] = 0x11
+
+	cart, err := NewCartridge(*game)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bus := newPPUBus(cart)
+
+	// single screen (upper), written serially to the control register
+	for _, bit := range []uint8{1, 0, 0, 0, 0} {
+		cart.WriteCPU(0x8000, bit)
+		// MMC1 ignores consecutive writes
+		cart.Mapper.(mapper.Clocked).ClockCPU()
+		cart.Mapper.(mapper.Clocked).ClockCPU()
+	}
+
+	bus.WriteByte(0x2000, 0x12)
+
+	if value := bus.ciram[0x400]; value != 0x12 {
+		t.Errorf("the mirroring selected by the mapper should be used; got=%02X, want=%02X", value, 0x12)
+	}
+	if value := bus.ReadByte(0x2C00); value != 0x12 {
+		t.Errorf("unexpected value read from another nametable in single screen; got=%02X, want=%02X", value, 0x12)
+	}
+}
+
+func TestPPUMemoryMap_PatternTables(t *testing.T) {
+	game := newTestGame(t, 1, 1)
+	game.CHR[0x1234] = 0x56
+
+	cart, err := NewCartridge(*game)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bus := newPPUBus(cart)
+
+	if value := bus.ReadByte(0x1234); value != 0x56 {
+		t.Errorf("unexpected value read from the CHR ROM; got=%02X, want=%02X", value, 0x56)
+	}
+	// the address has 14 bits
+	if value := bus.ReadByte(0x5234); value != 0x56 {
+		t.Errorf("unexpected value read from the CHR ROM mirror; got=%02X, want=%02X", value, 0x56)
+	}
+}
@@ -3,89 +3,444 @@ package nes
 import (
 	"bytes"
 	"fmt"
+	"image"
+	"io"
 	"log"
-	"math"
+	"sync/atomic"
+	"time"
 
 	"github.com/cd1/nes-emulator/cpu"
+	"github.com/cd1/nes-emulator/mapper"
 	"github.com/cd1/nes-emulator/parser"
+	"github.com/cd1/nes-emulator/ppu"
+	"github.com/cd1/nes-emulator/util"
 )
 
 const (
 	MemorySize          = 65536
-	PRGROMStart         = 0x8000
 	InitialStackAddress = 0x0100
 	ResetVectorAddress  = 0xFFFC
+
+	// nestest runs all of its tests without a PPU when started from here
+	NestestAutomationAddress = 0xC000
+
+	// number of cycles taken by the CPU to handle a reset
+	ResetCycles = 7
+
+	// NTSC CPU clock rate, in Hz
+	CPUClockRate = 1789773
 )
 
 type NES struct {
-	CPU    CPU
-	Memory Memory
+	CPU CPU
+	// Bus connects the CPU to the rest of the system. PowerOn connects the
+	// NES components to a new SystemBus.
+	Bus Bus
+	// Cartridge is the game inserted by PowerOn.
+	Cartridge *Cartridge
+	// PPU is connected by PowerOn to the bus and to the cartridge.
+	PPU *ppu.PPU
+	// PlayChoice is the PlayChoice-10 timer, set by PowerOn when the game
+	// was made for it.
+	PlayChoice *PlayChoice
+	// PlayChoiceTime is the play time bought by each credit of a
+	// PlayChoice-10 game. When it's 0, PlayChoiceDefaultPlayTime is used.
+	PlayChoiceTime time.Duration
+	// Save stores the battery-backed RAM of the cartridge, if it has one.
+	// It's read by PowerOn, from its start when it's an io.Seeker, and each
+	// write receives the whole RAM, which replaces the previous save (see
+	// SaveFile).
+	Save io.ReadWriter
+	// SaveInterval is the number of CPU cycles between the automatic
+	// flushes of the save. When it's 0, the save is only flushed when Run
+	// returns or when FlushSave is called.
+	SaveInterval uint64
+	// Frames makes Run return after the PPU finishes that many frames, so
+	// a game can run without anyone watching it (e.g. a test ROM whose
+	// result is on the screen). When it's 0, Run doesn't stop by itself.
+	Frames uint64
 
 	Verbose bool
-}
+	// NestestAutomation makes the CPU start at NestestAutomationAddress
+	// instead of the address in the reset vector.
+	NestestAutomation bool
+	// JamPolicy defines what happens when the CPU executes a JAM operation.
+	JamPolicy JamPolicy
+	// CPUVariant selects the flavour of 6502 emulated. The NES uses
+	// cpu.Variant2A03, the zero value, which has no decimal mode.
+	CPUVariant cpu.Variant
+	// CycleAccurate makes every bus access, including the dummy ones done
+	// by the CPU while it calculates addresses, take its own cycle. When
+	// it's not set, the cycles taken by an instruction pass at once after
+	// it's executed.
+	CycleAccurate bool
+
+	// the mapper of Cartridge, if it watches the CPU clock
+	clockedMapper mapper.Clocked
+
+	instruction cpu.Instruction
+	cycles      uint64
+	jammed      bool
+
+	nextSave uint64
+	// set by Stop, from any goroutine
+	stopped int32
+	// inserted by InsertCoin, from any goroutine
+	coins int32
+
+	// number of cycles passed and of bus accesses done in the current step
+	stepCycles   uint16
+	stepAccesses uint16
+	// set by a write to OAMDMAAddress, with the page to be copied
+	dmaPending bool
+	dmaPage    uint8
+	// peeking is set while the memory is only being inspected (e.g. by the
+	// trace), so the accesses don't take time
+	peeking bool
+
+	nmiLine    bool
+	nmiPending bool
+	irqLines   IRQSource
+}
+
+// PowerOn puts the system in the state it has when it's turned on with game
+// inserted: the memory is cleared, the game's cartridge is connected and the
+// CPU starts from the address in the reset vector. The number of cycles taken
+// is returned.
+func (nes *NES) PowerOn(game Game) (uint8, error) {
+	cart, err := NewCartridge(game)
+	if err != nil {
+		return 0, err
+	}
+
+	picture := ppu.New(newPPUBus(cart))
+
+	bus := NewSystemBus()
+	if err := connectDevices(bus, cart, picture, nes.startOAMDMA); err != nil {
+		return 0, err
+	}
 
+	if nes.Save != nil {
+		// the save may have been read by a previous PowerOn
+		if seeker, ok := nes.Save.(io.Seeker); ok {
+			if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+				return 0, err
+			}
+		}
+
+		if err := cart.LoadBattery(nes.Save); err != nil {
+			return 0, err
+		}
+	}
+
+	nes.Bus = bus
+	nes.Cartridge = cart
+	nes.PPU = picture
+	nes.PlayChoice = newPlayChoice(game, nes.PlayChoiceTime)
+	nes.clockedMapper, _ = cart.Mapper.(mapper.Clocked)
+
+	nes.CPU = CPU{}
+	nes.cycles = 0
+	nes.nextSave = nes.SaveInterval
+	nes.dmaPending = false
+	nes.nmiLine = false
+	nes.nmiPending = false
+	nes.irqLines = 0
+
+	// the reset sequence decrements the stack pointer from 0x00 to 0xFD
+	return nes.Reset(), nil
+}
+
+// Reset works like the reset button: the memory and the registers are
+// preserved, except for the stack pointer which is decremented by 3 and the
+// interrupt flag which is set. The CPU then continues from the address in
+// the reset vector. The PPU is reset too, so it starts a new frame when the
+// CPU is done. The number of cycles taken is returned.
 func (nes *NES) Reset() uint8 {
-	nes.CPU.ProgramCounter = 0xC000          // nes.ReadWord(ResetVectorAddress)
-	nes.CPU.StackPointer = math.MaxUint8 - 2 // math.MaxUint8
+	nes.startStep()
+
+	// the sequence of an interrupt, but the pushes are reads
+	nes.dummyRead(nes.CPU.ProgramCounter)
+	nes.dummyRead(nes.CPU.ProgramCounter)
+
+	for i := 0; i < 3; i++ {
+		nes.dummyRead(InitialStackAddress + uint16(nes.CPU.StackPointer))
+		nes.CPU.StackPointer--
+	}
+
 	nes.CPU.SetStatus(StatusInterrupt|StatusUnused, true)
+	nes.nmiPending = false
+	nes.jammed = false
+
+	if nes.NestestAutomation {
+		nes.CPU.ProgramCounter = NestestAutomationAddress
+	} else {
+		nes.CPU.ProgramCounter = nes.ReadWord(ResetVectorAddress)
+	}
+
+	nes.catchUp(ResetCycles)
 
-	nes.Memory = NewMemory(MemorySize)
+	if nes.PPU != nil {
+		nes.PPU.Reset()
+	}
 
-	return 7
+	return ResetCycles
 }
 
+// Run powers the system on with game and executes it until an error happens,
+// Stop is called or Frames are drawn. The save is flushed before it returns.
 func (nes *NES) Run(game Game) error {
-	resetCycles := nes.Reset()
+	if _, err := nes.PowerOn(game); err != nil {
+		return err
+	}
 
-	totalCycles := uint64(resetCycles)
+	atomic.StoreInt32(&nes.stopped, 0)
 
-	nes.loadGameInMemory(game)
+	for atomic.LoadInt32(&nes.stopped) == 0 {
+		if nes.Frames > 0 && nes.PPU.FrameCount() >= nes.Frames {
+			break
+		}
 
-	disassembleConfig := parser.DisassembleConfig{
-		DisplayBytes:         true,
-		DisplayMemoryAddress: true,
+		if _, err := nes.Step(); err != nil {
+			// the game may still have saved something before the error
+			nes.FlushSave()
+			return err
+		}
 	}
 
-	for {
-		op, err := parser.ConvertBinaryToOperation(bytes.NewReader(nes.Memory[nes.CPU.ProgramCounter:]))
-		if err != nil {
-			return err
+	return nes.FlushSave()
+}
+
+// Stop makes Run return before the next instruction. It can be called from
+// another goroutine.
+func (nes *NES) Stop() {
+	atomic.StoreInt32(&nes.stopped, 1)
+}
+
+// FlushSave writes the battery-backed RAM of the cartridge to Save, if it
+// has changed since it was last written.
+func (nes *NES) FlushSave() error {
+	if nes.Save == nil || nes.Cartridge == nil {
+		return nil
+	}
+
+	return nes.Cartridge.SaveBattery(nes.Save)
+}
+
+// Step handles a pending reset or interrupt, if any, and then executes the
+// next instruction. The operation is fetched through the memory map and executed
+// without allocating memory. If the CPU is jammed, nothing is executed but
+// the time still passes. When the instruction starts an OAM DMA, the CPU is
+// stalled until the DMA is done. The number of cycles taken, including the
+// stall, is returned.
+func (nes *NES) Step() (uint16, error) {
+	nes.startStep()
+
+	// the PlayChoice-10 resets the game instead of the next instruction
+	if nes.PlayChoice != nil && nes.updatePlayChoice() {
+		return uint16(nes.Reset()), nil
+	}
+
+	if nes.jammed {
+		nes.catchUp(jammedCycles)
+		return jammedCycles, nil
+	}
+
+	cycles := uint16(nes.pollInterrupts())
+	nes.catchUp(cycles)
+
+	startCycle := nes.cycles
+	startDot, startScanline := nes.ppuPosition()
+	pc := nes.CPU.ProgramCounter
+	opCode := nes.ReadByte(pc)
+
+	var arg0, arg1 uint8
+
+	switch cpu.OpCodes[opCode].Size() {
+	case 3:
+		arg0 = nes.ReadByte(pc + 1)
+		arg1 = nes.ReadByte(pc + 2)
+	case 2:
+		arg0 = nes.ReadByte(pc + 1)
+	default:
+		// the CPU always reads the byte after the op code
+		nes.dummyRead(pc + 1)
+	}
+
+	var err error
+
+	if nes.instruction, err = cpu.Decode(opCode, arg0, arg1); err != nil {
+		return cycles, err
+	}
+
+	if nes.Verbose {
+		if err = nes.printTrace(startCycle, startDot, startScanline); err != nil {
+			return cycles, err
 		}
+	}
 
-		if nes.Verbose {
-			var str bytes.Buffer
+	switch opCode {
+	case cpu.OpCodePLA, cpu.OpCodePLP, cpu.OpCodeRTS, cpu.OpCodeRTI, cpu.OpCodeJSR:
+		// the stack pointer is read before it's incremented (or before the
+		// return address is pushed, in JSR)
+		nes.dummyRead(InitialStackAddress + uint16(nes.CPU.StackPointer))
+	}
 
-			if err = parser.ConvertOperationToText(op, &str, disassembleConfig, nes.CPU.ProgramCounter, nes); err != nil {
-				return err
-			}
+	opCycles, err := nes.instruction.ExecuteIn(nes)
+	if err != nil {
+		return cycles, err
+	}
 
-			fmt.Printf("%-47v A:%02X X:%02X Y:%02X P:%02X SP:%02X PPU:%3v,%3v CYC:%v\n",
-				str.String(), nes.CPU.Accumulator, nes.CPU.IndexX, nes.CPU.IndexY, nes.CPU.Status, nes.CPU.StackPointer, -1, -1, totalCycles)
+	nes.finishInstruction(pc, opCycles)
+
+	cycles += uint16(opCycles)
+	nes.catchUp(cycles)
+
+	if nes.dmaPending {
+		cycles += nes.oamDMA()
+		nes.catchUp(cycles)
+	}
+
+	if nes.SaveInterval != 0 && nes.cycles >= nes.nextSave {
+		nes.nextSave = nes.cycles + nes.SaveInterval
+
+		if err := nes.FlushSave(); err != nil {
+			return cycles, err
 		}
+	}
 
-		cycles, err := op.ExecuteIn(nes)
-		if err != nil {
-			return err
+	return cycles, nil
+}
+
+// finishInstruction performs the dummy reads done by the CPU after the
+// operation itself has been executed, when it's fixing the program counter.
+func (nes *NES) finishInstruction(pc uint16, opCycles uint8) {
+	switch {
+	case nes.instruction.Code() == cpu.OpCodeRTS:
+		// the address pulled from the stack is read before it's incremented
+		nes.dummyRead(nes.CPU.ProgramCounter - 1)
+	case nes.instruction.AddressMode() == cpu.AddrModeRelative && opCycles > nes.instruction.Cycles():
+		// the branch was taken: the next op code is read while the offset is
+		// added to the program counter, and the address before fixing the
+		// high byte is read if a page was crossed
+		next := pc + uint16(nes.instruction.Size())
+		nes.dummyRead(next)
+
+		if !inSamePage(next, nes.CPU.ProgramCounter) {
+			nes.dummyRead(next&0xFF00 | nes.CPU.ProgramCounter&0x00FF)
 		}
+	}
+}
+
+// startStep resets the cycles counted for the current step.
+func (nes *NES) startStep() {
+	nes.stepCycles = 0
+	nes.stepAccesses = 0
+}
 
-		totalCycles += uint64(cycles)
+// busCycle makes a bus access take one cycle, in cycle-accurate mode.
+func (nes *NES) busCycle() {
+	if !nes.CycleAccurate || nes.peeking {
+		return
 	}
 
-	return nil
+	nes.tick()
+	nes.stepCycles++
+	nes.stepAccesses++
 }
 
-func (nes *NES) loadGameInMemory(game Game) {
-	switch game.Header.PRGBankCount() {
-	case 1:
-		// load ROM twice, in 0x8000-0xBFFF and also in 0xC000-0xFFFF
-		copy(nes.Memory[PRGROMStart:PRGROMStart+PRGBankSize], game.PRG)
-		copy(nes.Memory[PRGROMStart+PRGBankSize:PRGROMStart+2*PRGBankSize], game.PRG)
-	case 2:
-		// load ROM in 0x8000-0xFFFF
-		copy(nes.Memory[PRGROMStart:PRGROMStart+2*PRGBankSize], game.PRG)
-	default:
-		log.Printf("unexpected PRG bank count (%v); ROM was not loaded into memory", game.Header.PRGBankCount())
+// catchUp makes time pass until cycles have passed in the current step. In
+// cycle-accurate mode, the cycles already taken by bus accesses don't pass
+// again.
+func (nes *NES) catchUp(cycles uint16) {
+	for nes.stepCycles < cycles {
+		nes.tick()
+		nes.stepCycles++
+	}
+}
+
+// tick advances the system by one CPU cycle, in which the PPU draws 3 dots.
+func (nes *NES) tick() {
+	nes.cycles++
+
+	if nes.PPU != nil {
+		for i := 0; i < ppu.DotsPerCPUCycle; i++ {
+			nes.PPU.Clock()
+		}
+
+		nes.SetNMI(nes.PPU.NMI())
 	}
+
+	if nes.clockedMapper != nil {
+		nes.clockedMapper.ClockCPU()
+	}
+}
+
+// dummyRead reads from address only for the access to take a cycle; the
+// value is discarded. It's only done in cycle-accurate mode.
+func (nes *NES) dummyRead(address uint16) {
+	if nes.CycleAccurate && !nes.peeking {
+		nes.ReadByte(address)
+	}
+}
+
+// dummyWrite writes value to address only for the access to take a cycle.
+// It's only done in cycle-accurate mode.
+func (nes *NES) dummyWrite(address uint16, value uint8) {
+	if nes.CycleAccurate && !nes.peeking {
+		nes.WriteByte(address, value)
+	}
+}
+
+// Frame returns a copy of the last picture drawn by the PPU (see
+// ppu.PPU.Frame), or nil before the system is powered on.
+func (nes *NES) Frame() image.Image {
+	if nes.PPU == nil {
+		return nil
+	}
+
+	return nes.PPU.Frame()
+}
+
+// Cycles returns the number of CPU cycles since the system was powered on.
+func (nes *NES) Cycles() uint64 {
+	return nes.cycles
+}
+
+// ppuPosition returns the dot and the scanline of the PPU, or -1 for both
+// when there's no PPU.
+func (nes *NES) ppuPosition() (int, int) {
+	if nes.PPU == nil {
+		return -1, -1
+	}
+
+	dot, scanline := nes.PPU.Position()
+
+	return int(dot), int(scanline)
+}
+
+var traceDisassembleConfig = parser.DisassembleConfig{
+	DisplayBytes:         true,
+	DisplayMemoryAddress: true,
+}
+
+// printTrace prints the instruction about to be executed and the CPU state,
+// in the same format as sample/nestest.log. The instruction started at
+// startCycle, when the PPU was at startDot of startScanline.
+func (nes *NES) printTrace(startCycle uint64, startDot int, startScanline int) error {
+	var str bytes.Buffer
+
+	nes.peeking = true
+	defer func() { nes.peeking = false }()
+
+	if err := parser.ConvertOperationToText(&nes.instruction, &str, traceDisassembleConfig, nes.CPU.ProgramCounter, nes); err != nil {
+		return err
+	}
+
+	_, err := fmt.Printf("%-47v A:%02X X:%02X Y:%02X P:%02X SP:%02X PPU:%3v,%3v CYC:%v\n",
+		str.String(), nes.CPU.Accumulator, nes.CPU.IndexX, nes.CPU.IndexY, nes.CPU.Status, nes.CPU.StackPointer, startDot, startScanline, startCycle)
+
+	return err
 }
 
 func (nes *NES) GetAccumulator() uint8 {
@@ -138,6 +493,10 @@ func (nes *NES) SetStatus(value uint8) {
 	nes.CPU.Status = effectiveValue
 }
 
+func (nes *NES) GetVariant() cpu.Variant {
+	return nes.CPUVariant
+}
+
 func (nes *NES) IsStatusBreak() bool {
 	return nes.CPU.GetStatus(StatusBreak)
 }
@@ -202,47 +561,39 @@ func (nes *NES) SetStatusZero(isSet bool) {
 	nes.CPU.SetStatus(StatusZero, isSet)
 }
 
-func mapMemoryAddress(address uint16) uint16 {
-	var newAddress uint16
-
-	if address < 0x2000 {
-		// (0x0800, 0x0FFF): mirror of (0x0000, 0x07FF)
-		// ... x1
-		// (0x1800, 0x1FFF): mirror of (0x0000, 0x07FF)
-		newAddress = address % 0x800
-	} else if address >= 0x2008 && address < 0x4000 {
-		// (0x2008, 0x200F): mirror of (0x2000, 0x2007)
-		// ... x22
-		// (0x3FF8, 0x3FFF): mirror of (0x2000, 0x2007)
-		newAddress = 0x2000 + (address-0x2000)%0x08
-	} else {
-		newAddress = address
-	}
-
-	if address != newAddress {
-		log.Printf("memory mapped from %04X -> %04X", address, newAddress)
+func (nes *NES) ReadByte(address uint16) uint8 {
+	if nes.peeking {
+		return nes.Bus.Peek(address)
 	}
-	return newAddress
-}
 
-func (nes *NES) ReadByte(address uint16) uint8 {
-	return nes.Memory.ReadByte(mapMemoryAddress(address))
+	nes.busCycle()
+	return nes.Bus.ReadByte(address)
 }
 
 func (nes *NES) WriteByte(address uint16, value uint8) {
-	nes.Memory.WriteByte(address, value)
+	nes.busCycle()
+	nes.Bus.WriteByte(address, value)
 }
 
+// the CPU accesses the memory one byte at a time, so a word takes two cycles
+
 func (nes *NES) ReadWord(address uint16) uint16 {
-	return nes.Memory.ReadWord(mapMemoryAddress(address))
+	return util.JoinBytesInWord([]uint8{nes.ReadByte(address), nes.ReadByte(address + 1)})
 }
 
+// ReadWordSamePage reads a word without carrying to the high byte of the
+// address, so a word at 0x12FF is read from 0x12FF and 0x1200.
 func (nes *NES) ReadWordSamePage(address uint16) uint16 {
-	return nes.Memory.ReadWordSamePage(address)
+	highAddress := address&0xFF00 | uint16(uint8(address)+1)
+
+	return util.JoinBytesInWord([]uint8{nes.ReadByte(address), nes.ReadByte(highAddress)})
 }
 
 func (nes *NES) WriteWord(address uint16, value uint16) {
-	nes.Memory.WriteWord(address, value)
+	bytes := util.BreakWordIntoBytes(value)
+
+	nes.WriteByte(address, bytes[0])
+	nes.WriteByte(address+1, bytes[1])
 }
 
 func (nes *NES) PushByteToStack(value uint8) {
@@ -250,9 +601,13 @@ func (nes *NES) PushByteToStack(value uint8) {
 	nes.CPU.StackPointer--
 }
 
+// PushWordToStack pushes the high byte first, so the word is stored in
+// little-endian order.
 func (nes *NES) PushWordToStack(value uint16) {
-	nes.WriteWord(InitialStackAddress+uint16(nes.CPU.StackPointer-1), value)
-	nes.CPU.StackPointer -= 2
+	bytes := util.BreakWordIntoBytes(value)
+
+	nes.PushByteToStack(bytes[1])
+	nes.PushByteToStack(bytes[0])
 }
 
 func (nes *NES) PullByteFromStack() uint8 {
@@ -261,10 +616,10 @@ func (nes *NES) PullByteFromStack() uint8 {
 }
 
 func (nes *NES) PullWordFromStack() uint16 {
-	value := nes.ReadWord(InitialStackAddress + uint16(nes.CPU.StackPointer+1))
-	nes.CPU.StackPointer += 2
+	low := nes.PullByteFromStack()
+	high := nes.PullByteFromStack()
 
-	return value
+	return util.JoinBytesInWord([]uint8{low, high})
 }
 
 func (nes *NES) IncrementProgramCounter(value uint8) {
@@ -275,26 +630,29 @@ func inSamePage(addr0 uint16, addr1 uint16) bool {
 	return addr0&0xFF00 == addr1&0xFF00
 }
 
+// FetchOperand calculates the address used by op and reads the operand from
+// there, unless op only writes to it. In cycle-accurate mode, the dummy
+// accesses done by the CPU while calculating the address are also performed.
 func (nes *NES) FetchOperand(op cpu.Operation) (uint16, uint8, bool) {
 	var address uint16
 	var operand uint8
 	var pageCrossed bool
 
+	access := cpu.OpCodes[op.Code()].Access
+
 	switch op.AddressMode() {
 	case cpu.AddrModeAccumulator:
 		// operand not in memory
 		operand = nes.CPU.Accumulator
 	case cpu.AddrModeAbsolute:
 		address = op.WordArg()
-		operand = nes.ReadByte(address)
+		operand = nes.readOperand(address, access)
 	case cpu.AddrModeAbsoluteX:
-		address = op.WordArg() + uint16(nes.CPU.IndexX)
-		operand = nes.ReadByte(address)
-		pageCrossed = !inSamePage(op.WordArg(), address)
+		address, pageCrossed = nes.indexAddress(op.WordArg(), nes.CPU.IndexX, access)
+		operand = nes.readOperand(address, access)
 	case cpu.AddrModeAbsoluteY:
-		address = op.WordArg() + uint16(nes.CPU.IndexY)
-		operand = nes.ReadByte(address)
-		pageCrossed = !inSamePage(op.WordArg(), address)
+		address, pageCrossed = nes.indexAddress(op.WordArg(), nes.CPU.IndexY, access)
+		operand = nes.readOperand(address, access)
 	case cpu.AddrModeImmediate:
 		// operand not in memory
 		operand = op.ByteArg()
@@ -302,31 +660,65 @@ func (nes *NES) FetchOperand(op cpu.Operation) (uint16, uint8, bool) {
 		// no operand
 	case cpu.AddrModeIndirect:
 		address = nes.ReadWordSamePage(op.WordArg())
-		operand = nes.ReadByte(address)
+		operand = nes.readOperand(address, access)
 	case cpu.AddrModeIndirectX:
+		// the pointer is read before X is added to it
+		nes.dummyRead(uint16(op.ByteArg()))
 		address = nes.ReadWordSamePage(uint16(op.ByteArg() + nes.CPU.IndexX))
-		operand = nes.ReadByte(address)
+		operand = nes.readOperand(address, access)
 	case cpu.AddrModeIndirectY:
-		innerAddress := nes.ReadWordSamePage(uint16(op.ByteArg()))
-		address = innerAddress + uint16(nes.CPU.IndexY)
-		operand = nes.ReadByte(address)
-		pageCrossed = !inSamePage(innerAddress, address)
+		address, pageCrossed = nes.indexAddress(nes.ReadWordSamePage(uint16(op.ByteArg())), nes.CPU.IndexY, access)
+		operand = nes.readOperand(address, access)
 	case cpu.AddrModeRelative:
 		// operand not in memory
 		operand = op.ByteArg()
 		pageCrossed = !inSamePage(nes.CPU.ProgramCounter+uint16(int8(op.Size()+operand)), nes.CPU.ProgramCounter+uint16(op.Size()))
 	case cpu.AddrModeZero:
 		address = uint16(op.ByteArg())
-		operand = nes.ReadByte(address)
+		operand = nes.readOperand(address, access)
 	case cpu.AddrModeZeroX:
+		// the address is read before X is added to it
+		nes.dummyRead(uint16(op.ByteArg()))
 		address = uint16(op.ByteArg() + nes.CPU.IndexX)
-		operand = nes.ReadByte(address)
+		operand = nes.readOperand(address, access)
 	case cpu.AddrModeZeroY:
+		nes.dummyRead(uint16(op.ByteArg()))
 		address = uint16(op.ByteArg() + nes.CPU.IndexY)
-		operand = nes.ReadByte(address)
+		operand = nes.readOperand(address, access)
 	default:
 		log.Printf("failed to fetch operand: invalid address mode (%v)", op.AddressMode())
 	}
 
 	return address, operand, pageCrossed
 }
+
+// indexAddress adds index to baseAddress. The CPU adds it to the low byte
+// first and reads from the resulting address, then fixes the high byte if a
+// page was crossed; the read only counts when it's not corrected afterwards.
+func (nes *NES) indexAddress(baseAddress uint16, index uint8, access cpu.MemoryAccess) (uint16, bool) {
+	address := baseAddress + uint16(index)
+	pageCrossed := !inSamePage(baseAddress, address)
+
+	if pageCrossed || access == cpu.MemoryAccessWrite || access == cpu.MemoryAccessReadModifyWrite {
+		nes.dummyRead(baseAddress&0xFF00 | address&0x00FF)
+	}
+
+	return address, pageCrossed
+}
+
+func (nes *NES) readOperand(address uint16, access cpu.MemoryAccess) uint8 {
+	switch {
+	case access == cpu.MemoryAccessRead:
+		return nes.ReadByte(address)
+	case access == cpu.MemoryAccessReadModifyWrite:
+		operand := nes.ReadByte(address)
+		// the unmodified value is written back while the new one is calculated
+		nes.dummyWrite(address, operand)
+		return operand
+	case nes.peeking:
+		// the trace displays the value even when the operation doesn't read it
+		return nes.ReadByte(address)
+	default:
+		return 0
+	}
+}
@@ -1,6 +1,17 @@
 package nes
 
-import "testing"
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cd1/nes-emulator/cpu"
+	"github.com/cd1/nes-emulator/parser"
+	"github.com/cd1/nes-emulator/ppu"
+)
 
 func TestNES_Status(t *testing.T) {
 	var system NES
@@ -32,3 +43,577 @@ func BenchmarkNES_SetStatus(b *testing.B) {
 		system.SetStatus(0xFF)
 	}
 }
+
+func newInterruptTestNES() *NES {
+	system := &NES{
+		Bus: newFlatTestBus(),
+	}
+
+	system.CPU.ProgramCounter = 0x8123
+	system.CPU.StackPointer = 0xFD
+	system.SetStatus(StatusCarry)
+	system.WriteWord(cpu.NMIVectorAddress, 0x9000)
+	system.WriteWord(cpu.IRQVectorAddress, 0xA000)
+
+	return system
+}
+
+func TestNES_NMI(t *testing.T) {
+	system := newInterruptTestNES()
+
+	system.SetNMI(true)
+
+	if cycles := system.pollInterrupts(); cycles != cpu.InterruptCycles {
+		t.Errorf("unexpected NMI cycles; got=%v, want=%v", cycles, cpu.InterruptCycles)
+	}
+	if pc := system.CPU.ProgramCounter; pc != 0x9000 {
+		t.Errorf("unexpected PC after NMI; got=%04X, want=%04X", pc, 0x9000)
+	}
+	if sp := system.CPU.StackPointer; sp != 0xFA {
+		t.Errorf("unexpected SP after NMI; got=%02X, want=%02X", sp, 0xFA)
+	}
+	if st := system.PullByteFromStack(); st != StatusCarry|StatusUnused {
+		t.Errorf("unexpected status pushed by NMI; got=%02X, want=%02X", st, StatusCarry|StatusUnused)
+	}
+	if pc := system.PullWordFromStack(); pc != 0x8123 {
+		t.Errorf("unexpected PC pushed by NMI; got=%04X, want=%04X", pc, 0x8123)
+	}
+	if !system.IsStatusInterrupt() {
+		t.Error("interrupt flag should be set after NMI")
+	}
+
+	// the line is still asserted but there's no new edge
+	if cycles := system.pollInterrupts(); cycles != 0 {
+		t.Errorf("NMI should not be handled again without a new edge; got=%v cycles", cycles)
+	}
+
+	system.SetNMI(false)
+	system.SetNMI(true)
+
+	if cycles := system.pollInterrupts(); cycles != cpu.InterruptCycles {
+		t.Errorf("NMI should be handled after a new edge; got=%v cycles, want=%v", cycles, cpu.InterruptCycles)
+	}
+}
+
+func TestNES_IRQ(t *testing.T) {
+	system := newInterruptTestNES()
+
+	system.SetStatusInterrupt(true)
+	system.SetIRQ(IRQSourceMapper, true)
+
+	if cycles := system.pollInterrupts(); cycles != 0 {
+		t.Errorf("IRQ should be ignored while the interrupt flag is set; got=%v cycles", cycles)
+	}
+
+	system.SetStatusInterrupt(false)
+
+	if cycles := system.pollInterrupts(); cycles != cpu.InterruptCycles {
+		t.Errorf("unexpected IRQ cycles; got=%v, want=%v", cycles, cpu.InterruptCycles)
+	}
+	if pc := system.CPU.ProgramCounter; pc != 0xA000 {
+		t.Errorf("unexpected PC after IRQ; got=%04X, want=%04X", pc, 0xA000)
+	}
+	if st := system.PullByteFromStack(); st&StatusBreak != 0x00 {
+		t.Errorf("IRQ should push the status with the break flag clear; got=%02X", st)
+	}
+
+	// the line is level-triggered, so it fires again as soon as the flag is clear
+	system.SetStatusInterrupt(false)
+	system.SetIRQ(IRQSourceAPUFrameCounter, true)
+	system.SetIRQ(IRQSourceMapper, false)
+
+	if !system.IsIRQAsserted() {
+		t.Error("IRQ line should remain asserted while any source holds it")
+	}
+	if cycles := system.pollInterrupts(); cycles != cpu.InterruptCycles {
+		t.Errorf("IRQ should be handled while the line is asserted; got=%v cycles, want=%v", cycles, cpu.InterruptCycles)
+	}
+
+	system.SetStatusInterrupt(false)
+	system.SetIRQ(IRQSourceAPUFrameCounter, false)
+
+	if cycles := system.pollInterrupts(); cycles != 0 {
+		t.Errorf("IRQ should not be handled after the line is released; got=%v cycles", cycles)
+	}
+}
+
+func TestNES_MapperIRQ(t *testing.T) {
+	game := newTestGame(t, 2, 1)
+	// MMC3
+	game.Header[6] = 0x40
+	// IRQ vector, in the last 8 kiB bank
+	game.PRG[0x7FFE] = 0x00
+	game.PRG[0x7FFF] = 0xE0
+
+	var system NES
+
+	if _, err := system.PowerOn(*game); err != nil {
+		t.Fatal(err)
+	}
+
+	system.SetStatusInterrupt(false)
+
+	// IRQ in the next scanline
+	system.WriteByte(0xC000, 0x00)
+	system.WriteByte(0xC001, 0x00)
+	system.WriteByte(0xE001, 0x00)
+
+	system.Cartridge.ReadPPU(0x0000)
+	for i := 0; i < 10; i++ {
+		system.tick()
+	}
+	system.Cartridge.ReadPPU(0x1000)
+
+	if cycles := system.pollInterrupts(); cycles != cpu.InterruptCycles {
+		t.Errorf("unexpected IRQ cycles; got=%v, want=%v", cycles, cpu.InterruptCycles)
+	}
+	if pc := system.CPU.ProgramCounter; pc != 0xE000 {
+		t.Errorf("unexpected PC after the mapper IRQ; got=%04X, want=%04X", pc, 0xE000)
+	}
+
+	// acknowledge
+	system.WriteByte(0xE000, 0x00)
+	system.SetStatusInterrupt(false)
+
+	if cycles := system.pollInterrupts(); cycles != 0 {
+		t.Errorf("IRQ should not be handled after the mapper releases the line; got=%v cycles", cycles)
+	}
+}
+
+func TestNES_PowerOn(t *testing.T) {
+	game := newTestGame(t, 1, 0)
+	// reset vector, as seen from 0xC000-0xFFFF
+	game.PRG[0x3FFC] = 0x34
+	game.PRG[0x3FFD] = 0x82
+
+	var system NES
+
+	cycles, err := system.PowerOn(*game)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cycles != ResetCycles {
+		t.Errorf("unexpected power on cycles; got=%v, want=%v", cycles, ResetCycles)
+	}
+	if pc := system.CPU.ProgramCounter; pc != 0x8234 {
+		t.Errorf("unexpected PC after power on; got=%04X, want=%04X", pc, 0x8234)
+	}
+	if sp := system.CPU.StackPointer; sp != 0xFD {
+		t.Errorf("unexpected SP after power on; got=%02X, want=%02X", sp, 0xFD)
+	}
+	if st := system.CPU.Status; st != StatusInterrupt|StatusUnused {
+		t.Errorf("unexpected status after power on; got=%02X, want=%02X", st, StatusInterrupt|StatusUnused)
+	}
+
+	system.NestestAutomation = true
+	if _, err := system.PowerOn(*game); err != nil {
+		t.Fatal(err)
+	}
+
+	if pc := system.CPU.ProgramCounter; pc != NestestAutomationAddress {
+		t.Errorf("unexpected PC after power on in nestest automation mode; got=%04X, want=%04X", pc, NestestAutomationAddress)
+	}
+}
+
+func TestNES_Reset(t *testing.T) {
+	game := newTestGame(t, 1, 0)
+	game.PRG[0x3FFC] = 0x00
+	game.PRG[0x3FFD] = 0xC0
+
+	var system NES
+
+	if _, err := system.PowerOn(*game); err != nil {
+		t.Fatal(err)
+	}
+
+	system.CPU.ProgramCounter = 0xC123
+	system.CPU.Accumulator = 0x12
+	system.SetStatus(StatusCarry)
+	system.WriteByte(0x0123, 0x45)
+
+	if cycles := system.Reset(); cycles != ResetCycles {
+		t.Errorf("unexpected reset cycles; got=%v, want=%v", cycles, ResetCycles)
+	}
+	if pc := system.CPU.ProgramCounter; pc != 0xC000 {
+		t.Errorf("unexpected PC after reset; got=%04X, want=%04X", pc, 0xC000)
+	}
+	if sp := system.CPU.StackPointer; sp != 0xFA {
+		t.Errorf("unexpected SP after reset; got=%02X, want=%02X", sp, 0xFA)
+	}
+	if st := system.CPU.Status; st != StatusCarry|StatusInterrupt|StatusUnused {
+		t.Errorf("unexpected status after reset; got=%02X, want=%02X", st, StatusCarry|StatusInterrupt|StatusUnused)
+	}
+	if a := system.CPU.Accumulator; a != 0x12 {
+		t.Errorf("accumulator should be preserved after reset; got=%02X, want=%02X", a, 0x12)
+	}
+	if value := system.ReadByte(0x0123); value != 0x45 {
+		t.Errorf("RAM should be preserved after reset; got=%02X, want=%02X", value, 0x45)
+	}
+}
+
+const nesTestLogFileName = "sample/nestest.log"
+
+type nesTestState struct {
+	programCounter uint16
+	accumulator    uint8
+	indexX         uint8
+	indexY         uint8
+	status         uint8
+	stackPointer   uint8
+	dot            uint16
+	scanline       uint16
+	cycles         uint64
+}
+
+func loadNesTestLog(t testing.TB) []nesTestState {
+	logFile, err := os.Open(nesTestLogFileName)
+	if err != nil {
+		t.Skipf("failed to open nestest log: %v", err)
+	}
+	defer logFile.Close()
+
+	var states []nesTestState
+
+	scanner := bufio.NewScanner(logFile)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		var state nesTestState
+
+		if _, err := fmt.Sscanf(line[:4], "%X", &state.programCounter); err != nil {
+			t.Fatalf("invalid nestest log line %q: %v", line, err)
+		}
+
+		registers := line[strings.Index(line, "A:"):]
+		if _, err := fmt.Sscanf(registers, "A:%X X:%X Y:%X P:%X SP:%X", &state.accumulator, &state.indexX, &state.indexY, &state.status, &state.stackPointer); err != nil {
+			t.Fatalf("invalid nestest log line %q: %v", line, err)
+		}
+
+		ppuPosition := line[strings.Index(line, "PPU:"):]
+		if _, err := fmt.Sscanf(ppuPosition, "PPU:%d,%d", &state.dot, &state.scanline); err != nil {
+			t.Fatalf("invalid nestest log line %q: %v", line, err)
+		}
+
+		cycles := strings.TrimSpace(line[strings.Index(line, "CYC:"):])
+		if _, err := fmt.Sscanf(cycles, "CYC:%d", &state.cycles); err != nil {
+			t.Fatalf("invalid nestest log line %q: %v", line, err)
+		}
+
+		states = append(states, state)
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	return states
+}
+
+func newNesTestNES(t testing.TB) *NES {
+	nesTestFile, err := os.Open(nesTestFileName)
+	if err != nil {
+		t.Skipf("failed to open nestest: %v", err)
+	}
+	defer nesTestFile.Close()
+
+	game, err := LoadGame(nesTestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	system := &NES{
+		NestestAutomation: true,
+	}
+	if _, err := system.PowerOn(*game); err != nil {
+		t.Fatal(err)
+	}
+
+	return system
+}
+
+func TestNES_Step(t *testing.T) {
+	states := loadNesTestLog(t)
+
+	for _, cycleAccurate := range []bool{false, true} {
+		t.Run(fmt.Sprintf("CycleAccurate=%v", cycleAccurate), func(t *testing.T) {
+			system := newNesTestNES(t)
+			system.CycleAccurate = cycleAccurate
+
+			for i, want := range states {
+				dot, scanline := system.PPU.Position()
+
+				got := nesTestState{
+					programCounter: system.CPU.ProgramCounter,
+					accumulator:    system.CPU.Accumulator,
+					indexX:         system.CPU.IndexX,
+					indexY:         system.CPU.IndexY,
+					status:         system.CPU.Status,
+					stackPointer:   system.CPU.StackPointer,
+					dot:            dot,
+					scanline:       scanline,
+					cycles:         system.Cycles(),
+				}
+
+				if got != want {
+					t.Fatalf("unexpected CPU state before nestest log line %v; got=%+v, want=%+v", i+1, got, want)
+				}
+
+				cycles, err := system.Step()
+				if err != nil {
+					t.Fatalf("failed to execute nestest log line %v: %v", i+1, err)
+				}
+
+				// every cycle taken by the instruction must come from a bus access
+				if cycleAccurate && system.stepAccesses != cycles {
+					t.Fatalf("unexpected bus accesses in nestest log line %v; got=%v, want=%v", i+1, system.stepAccesses, cycles)
+				}
+			}
+
+			// nestest stores the result of the tests in 0x02 and 0x03
+			if result := system.ReadWord(0x0002); result != 0x0000 {
+				t.Errorf("nestest reported an error; got=%04X, want=%04X", result, 0x0000)
+			}
+		})
+	}
+}
+
+func TestNES_StepAllocations(t *testing.T) {
+	system := newNesTestNES(t)
+
+	allocs := testing.AllocsPerRun(5000, func() {
+		if _, err := system.Step(); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if allocs != 0 {
+		t.Errorf("unexpected allocations per step; got=%v, want=%v", allocs, 0)
+	}
+}
+
+func BenchmarkNES_Step(b *testing.B) {
+	states := loadNesTestLog(b)
+	system := newNesTestNES(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n, i := 0, 0; n < b.N; n, i = n+1, i+1 {
+		if i == len(states) {
+			// restart nestest before it runs past its last test
+			b.StopTimer()
+			system = newNesTestNES(b)
+			i = 0
+			b.StartTimer()
+		}
+
+		if _, err := system.Step(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestNES_PPUNMI(t *testing.T) {
+	game := newTestGame(t, 1, 1)
+	// NMI vector, as seen from 0xC000-0xFFFF
+	game.PRG[0x3FFA] = 0x00
+	game.PRG[0x3FFB] = 0xE0
+
+	var system NES
+
+	if _, err := system.PowerOn(*game); err != nil {
+		t.Fatal(err)
+	}
+
+	system.WriteByte(PPURegistersStart+ppu.ControlRegister, ppu.ControlNMI)
+
+	for {
+		if _, scanline := system.PPU.Position(); scanline > ppu.VBlankScanline {
+			break
+		}
+
+		system.tick()
+	}
+
+	if cycles := system.pollInterrupts(); cycles != cpu.InterruptCycles {
+		t.Errorf("unexpected NMI cycles; got=%v, want=%v", cycles, cpu.InterruptCycles)
+	}
+	if pc := system.CPU.ProgramCounter; pc != 0xE000 {
+		t.Errorf("unexpected PC after the VBlank NMI; got=%04X, want=%04X", pc, 0xE000)
+	}
+	if cycles := system.pollInterrupts(); cycles != 0 {
+		t.Errorf("NMI should be handled only once per VBlank; got=%v cycles", cycles)
+	}
+}
+
+func TestNES_Frame(t *testing.T) {
+	game := newTestGame(t, 1, 1)
+
+	var system NES
+
+	if frame := system.Frame(); frame != nil {
+		t.Errorf("there should be no frame before the power on; got=%v", frame.Bounds())
+	}
+
+	if _, err := system.PowerOn(*game); err != nil {
+		t.Fatal(err)
+	}
+
+	// backdrop
+	system.WriteByte(PPURegistersStart+ppu.AddressRegister, 0x3F)
+	system.WriteByte(PPURegistersStart+ppu.AddressRegister, 0x00)
+	system.WriteByte(PPURegistersStart+ppu.DataRegister, 0x21)
+	system.WriteByte(PPURegistersStart+ppu.AddressRegister, 0x20)
+	system.WriteByte(PPURegistersStart+ppu.AddressRegister, 0x00)
+
+	for system.PPU.FrameCount() < 2 {
+		system.tick()
+	}
+
+	frame := system.Frame()
+
+	if bounds := frame.Bounds(); bounds.Dx() != ppu.Width || bounds.Dy() != ppu.Height {
+		t.Errorf("unexpected frame size; got=%vx%v, want=%vx%v", bounds.Dx(), bounds.Dy(), ppu.Width, ppu.Height)
+	}
+	if c := frame.At(128, 120); c != ppu.MasterPalette[0x21] {
+		t.Errorf("unexpected backdrop color; got=%v, want=%v", c, ppu.MasterPalette[0x21])
+	}
+
+	// the frame returned isn't drawn over by the next ones
+	system.WriteByte(PPURegistersStart+ppu.AddressRegister, 0x3F)
+	system.WriteByte(PPURegistersStart+ppu.AddressRegister, 0x00)
+	system.WriteByte(PPURegistersStart+ppu.DataRegister, 0x16)
+	system.WriteByte(PPURegistersStart+ppu.AddressRegister, 0x20)
+	system.WriteByte(PPURegistersStart+ppu.AddressRegister, 0x00)
+
+	for system.PPU.FrameCount() < 4 {
+		system.tick()
+	}
+
+	if c := frame.At(128, 120); c != ppu.MasterPalette[0x21] {
+		t.Errorf("the frame returned should not change; got=%v, want=%v", c, ppu.MasterPalette[0x21])
+	}
+	if c := system.Frame().At(128, 120); c != ppu.MasterPalette[0x16] {
+		t.Errorf("unexpected backdrop color in the next frame; got=%v, want=%v", c, ppu.MasterPalette[0x16])
+	}
+}
+
+func TestNES_RunFrames(t *testing.T) {
+	game := newTestGame(t, 1, 1)
+	// JMP $8000
+	copy(game.PRG, []uint8{0x4C, 0x00, 0x80})
+	game.PRG[0x3FFC] = 0x00
+	game.PRG[0x3FFD] = 0x80
+
+	system := NES{Frames: 3}
+
+	if err := system.Run(*game); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := system.PPU.FrameCount(); got != system.Frames {
+		t.Errorf("unexpected number of frames when Run returns; got=%v, want=%v", got, system.Frames)
+	}
+}
+
+// splitProgram draws a status bar over the nametable 0 and, below the
+// sprite 0 in the scanline 100, the nametable 1 scrolled 4 pixels to the
+// left, which is split in the middle of the frame like the games do. It
+// starts at $C000.
+var splitProgram = []string{
+	"SEI",
+	// wait for the PPU to warm up
+	"BIT $2002", "BPL $FB",
+	"BIT $2002", "BPL $FB",
+	// palette 0: white; palette 1: red
+	"LDA #$3F", "STA $2006", "LDA #$00", "STA $2006",
+	"LDA #$0F", "STA $2007",
+	"LDA #$30", "STA $2007", "STA $2007", "STA $2007",
+	"LDA #$0F", "STA $2007",
+	"LDA #$16", "STA $2007",
+	// fill both nametables with the tile 1
+	"LDA #$20", "STA $2006", "LDA #$00", "STA $2006",
+	"LDA #$01", "LDY #$08",
+	"LDX #$00",
+	"STA $2007", "INX", "BNE $FA",
+	"DEY", "BNE $F5",
+	// the nametable 0 uses the palette 0 and the nametable 1 uses the
+	// palette 1
+	"LDA #$23", "STA $2006", "LDA #$C0", "STA $2006",
+	"LDA #$00", "LDX #$40",
+	"STA $2007", "DEX", "BNE $FA",
+	"LDA #$27", "STA $2006", "LDA #$C0", "STA $2006",
+	"LDA #$55", "LDX #$40",
+	"STA $2007", "DEX", "BNE $FA",
+	// sprite 0: tile 2 in (128, 100)
+	"LDA #$00", "STA $2003",
+	"LDA #$63", "STA $2004",
+	"LDA #$02", "STA $2004",
+	"LDA #$00", "STA $2004",
+	"LDA #$80", "STA $2004",
+	// each frame: wait for VBlank, show the nametable 0 from the top and
+	// turn rendering on
+	"BIT $2002", "BPL $FB",
+	"LDA #$00", "STA $2000", "STA $2005", "STA $2005",
+	"LDA #$1E", "STA $2001",
+	// wait for the sprite 0 hit of this frame
+	"BIT $2002", "BVS $FB",
+	"BIT $2002", "BVC $FB",
+	// scroll X to 4 and switch to the nametable 1
+	"LDA #$04", "STA $2005", "LDA #$00", "STA $2005",
+	"LDA #$01", "STA $2000",
+	"JMP $C089",
+}
+
+func TestNES_RenderSplit(t *testing.T) {
+	var program bytes.Buffer
+
+	if err := parser.Assemble(strings.NewReader(strings.Join(splitProgram, "\n")), &program); err != nil {
+		t.Fatal(err)
+	}
+
+	game := newTestGame(t, 1, 1)
+	// vertical mirroring: the nametables 0 and 1 are side by side
+	game.Header[6] |= 0x01
+	copy(game.PRG, program.Bytes())
+	// reset vector: $C000
+	game.PRG[0x3FFC] = 0x00
+	game.PRG[0x3FFD] = 0xC0
+
+	for i := 0; i < 8; i++ {
+		// tile 1, low plane
+		game.CHR[0x0010+i] = 0xFF
+		// tile 2, high plane
+		game.CHR[0x0028+i] = 0xFF
+	}
+
+	system := NES{Frames: 5}
+
+	if err := system.Run(*game); err != nil {
+		t.Fatal(err)
+	}
+
+	frame := system.Frame()
+
+	tests := []struct {
+		x     int
+		y     int
+		color uint8
+	}{
+		// status bar
+		{0, 0, 0x30},
+		{255, 99, 0x30},
+		// the split starts in the next scanline after the sprite 0 hit
+		{0, 101, 0x16},
+		{251, 101, 0x16},
+		// the last 4 pixels are from the nametable 0, on the right
+		{252, 101, 0x30},
+		{255, 239, 0x30},
+		{0, 239, 0x16},
+	}
+
+	for _, test := range tests {
+		if c := frame.At(test.x, test.y); c != ppu.MasterPalette[test.color] {
+			t.Errorf("unexpected color in (%v, %v); got=%v, want=%v", test.x, test.y, c, ppu.MasterPalette[test.color])
+		}
+	}
+}
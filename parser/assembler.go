@@ -50,148 +50,17 @@ func convertTextToOperation(line string) (cpu.Operation, error) {
 		return nil, err
 	}
 
-	var op cpu.Operation
-
-	switch {
-	case cpu.IsMnemonicValidADC(mnemonic):
-		op, err = cpu.NewADCFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidAND(mnemonic):
-		op, err = cpu.NewANDFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidASL(mnemonic):
-		op, err = cpu.NewASLFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidBCC(mnemonic):
-		op, err = cpu.NewBCCFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidBCS(mnemonic):
-		op, err = cpu.NewBCSFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidBEQ(mnemonic):
-		op, err = cpu.NewBEQFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidBIT(mnemonic):
-		op, err = cpu.NewBITFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidBMI(mnemonic):
-		op, err = cpu.NewBMIFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidBNE(mnemonic):
-		op, err = cpu.NewBNEFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidBPL(mnemonic):
-		op, err = cpu.NewBPLFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidBRK(mnemonic):
-		op, err = cpu.NewBRKFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidBVC(mnemonic):
-		op, err = cpu.NewBVCFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidBVS(mnemonic):
-		op, err = cpu.NewBVSFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidCLC(mnemonic):
-		op, err = cpu.NewCLCFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidCLD(mnemonic):
-		op, err = cpu.NewCLDFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidCLI(mnemonic):
-		op, err = cpu.NewCLIFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidCLV(mnemonic):
-		op, err = cpu.NewCLVFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidCMP(mnemonic):
-		op, err = cpu.NewCMPFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidCPX(mnemonic):
-		op, err = cpu.NewCPXFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidCPY(mnemonic):
-		op, err = cpu.NewCPYFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidDCP(mnemonic):
-		op, err = cpu.NewDCPFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidDEC(mnemonic):
-		op, err = cpu.NewDECFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidDEX(mnemonic):
-		op, err = cpu.NewDEXFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidDEY(mnemonic):
-		op, err = cpu.NewDEYFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidEOR(mnemonic):
-		op, err = cpu.NewEORFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidINC(mnemonic):
-		op, err = cpu.NewINCFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidINX(mnemonic):
-		op, err = cpu.NewINXFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidINY(mnemonic):
-		op, err = cpu.NewINYFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidISB(mnemonic):
-		op, err = cpu.NewISBFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidJMP(mnemonic):
-		op, err = cpu.NewJMPFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidJSR(mnemonic):
-		op, err = cpu.NewJSRFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidLAX(mnemonic):
-		op, err = cpu.NewLAXFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidLDA(mnemonic):
-		op, err = cpu.NewLDAFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidLDX(mnemonic):
-		op, err = cpu.NewLDXFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidLDY(mnemonic):
-		op, err = cpu.NewLDYFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidLSR(mnemonic):
-		op, err = cpu.NewLSRFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidNOP(mnemonic):
-		op, err = cpu.NewNOPFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidORA(mnemonic):
-		op, err = cpu.NewORAFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidPHA(mnemonic):
-		op, err = cpu.NewPHAFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidPHP(mnemonic):
-		op, err = cpu.NewPHPFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidPLA(mnemonic):
-		op, err = cpu.NewPLAFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidPLP(mnemonic):
-		op, err = cpu.NewPLPFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidRLA(mnemonic):
-		op, err = cpu.NewRLAFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidROL(mnemonic):
-		op, err = cpu.NewROLFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidROR(mnemonic):
-		op, err = cpu.NewRORFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidRRA(mnemonic):
-		op, err = cpu.NewRRAFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidRTI(mnemonic):
-		op, err = cpu.NewRTIFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidRTS(mnemonic):
-		op, err = cpu.NewRTSFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidSAX(mnemonic):
-		op, err = cpu.NewSAXFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidSBC(mnemonic):
-		op, err = cpu.NewSBCFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidSEC(mnemonic):
-		op, err = cpu.NewSECFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidSED(mnemonic):
-		op, err = cpu.NewSEDFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidSEI(mnemonic):
-		op, err = cpu.NewSEIFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidSLO(mnemonic):
-		op, err = cpu.NewSLOFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidSRE(mnemonic):
-		op, err = cpu.NewSREFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidSTA(mnemonic):
-		op, err = cpu.NewSTAFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidSTX(mnemonic):
-		op, err = cpu.NewSTXFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidSTY(mnemonic):
-		op, err = cpu.NewSTYFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidTAX(mnemonic):
-		op, err = cpu.NewTAYFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidTAY(mnemonic):
-		op, err = cpu.NewTAYFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidTSX(mnemonic):
-		op, err = cpu.NewTSXFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidTXA(mnemonic):
-		op, err = cpu.NewTXAFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidTXS(mnemonic):
-		op, err = cpu.NewTXSFromBytes(addrMode, arg0, arg1)
-	case cpu.IsMnemonicValidTYA(mnemonic):
-		op, err = cpu.NewTYAFromBytes(addrMode, arg0, arg1)
-	default:
-		return nil, cpu.InvalidMnemonicError{
-			Mnemonic: mnemonic,
-		}
+	opCode, err := cpu.LookupOpCode(mnemonic, addrMode)
+	if err != nil {
+		return nil, err
 	}
 
+	inst, err := cpu.Decode(opCode, arg0, arg1)
 	if err != nil {
 		return nil, err
 	}
 
-	return op, nil
+	return &inst, nil
 }
 
 func breakdownText(line string) (string, uint8, uint8, uint8, error) {
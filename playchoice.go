@@ -0,0 +1,140 @@
+package nes
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// the PROM has 16 bytes of data followed by 16 bytes of counter output
+	PlayChoicePROMSize     = 32
+	playChoicePROMDataSize = 16
+
+	// the hint screen shown by the PlayChoice-10 in its upper screen is a
+	// nametable of 32x30 tiles followed by its 64-byte attribute table
+	PlayChoiceHintScreenSize = 32*30 + 64
+
+	// play time bought by a credit when NES.PlayChoiceTime isn't set
+	PlayChoiceDefaultPlayTime = 300 * time.Second
+)
+
+// PlayChoiceINSTROM is the instruction ROM of a PlayChoice-10 game, which
+// holds the hint screen and the data shown by the PlayChoice-10 menu. The
+// play time isn't read from it: the PlayChoice-10 sets it with switches.
+type PlayChoiceINSTROM []uint8
+
+// HintScreen returns the nametable of the hint screen, one byte per tile row
+// by row, followed by its attribute table.
+func (rom PlayChoiceINSTROM) HintScreen() []uint8 {
+	if len(rom) < PlayChoiceHintScreenSize {
+		return nil
+	}
+
+	return rom[:PlayChoiceHintScreenSize]
+}
+
+// PlayChoicePROM is the security PROM of a PlayChoice-10 game, checked by
+// the PlayChoice-10 before it runs the game. It's missing from most dumps.
+type PlayChoicePROM []uint8
+
+func (prom PlayChoicePROM) Data() []uint8 {
+	if len(prom) < PlayChoicePROMSize {
+		return nil
+	}
+
+	return prom[:playChoicePROMDataSize]
+}
+
+func (prom PlayChoicePROM) CounterOut() []uint8 {
+	if len(prom) < PlayChoicePROMSize {
+		return nil
+	}
+
+	return prom[playChoicePROMDataSize:PlayChoicePROMSize]
+}
+
+// PlayChoice is the PlayChoice-10 timer around a game. The game starts in
+// the attract mode, where it runs its demo for free; each coin inserted buys
+// one credit, and each credit buys the play time of the game. When the time
+// is over and there are no credits left, the game is reset back to the
+// attract mode.
+type PlayChoice struct {
+	INSTROM PlayChoiceINSTROM
+	PROM    PlayChoicePROM
+	// PlayTime is the time bought by each credit.
+	PlayTime time.Duration
+
+	credits uint8
+	playing bool
+	// cycle when the current credit ends
+	timerEnd uint64
+}
+
+func newPlayChoice(game Game, playTime time.Duration) *PlayChoice {
+	if !game.Header.HasPlayChoice() {
+		return nil
+	}
+
+	if playTime <= 0 {
+		playTime = PlayChoiceDefaultPlayTime
+	}
+
+	return &PlayChoice{
+		INSTROM:  game.PlayChoiceINSTROM,
+		PROM:     game.PlayChoicePROM,
+		PlayTime: playTime,
+	}
+}
+
+func (pc *PlayChoice) Credits() uint8 {
+	return pc.credits
+}
+
+func (pc *PlayChoice) IsAttractMode() bool {
+	return !pc.playing
+}
+
+// playTimeCycles returns the play time of a credit in CPU cycles.
+func (pc *PlayChoice) playTimeCycles() uint64 {
+	return uint64(pc.PlayTime/time.Second) * CPUClockRate
+}
+
+// InsertCoin adds a credit to the PlayChoice-10 timer. It's ignored when the
+// game isn't a PlayChoice-10 game. It may be called from any goroutine, even
+// before the system is turned on; the coin is counted before the next
+// instruction.
+func (nes *NES) InsertCoin() {
+	atomic.AddInt32(&nes.coins, 1)
+}
+
+// updatePlayChoice counts the inserted coins and spends the credits as the
+// play time passes. It checks whether the system must be reset, which starts
+// the game when the first credit is bought and goes back to the attract mode
+// when the last one ends.
+func (nes *NES) updatePlayChoice() bool {
+	pc := nes.PlayChoice
+
+	for coins := atomic.SwapInt32(&nes.coins, 0); coins > 0; coins-- {
+		if pc.credits < 0xFF {
+			pc.credits++
+		}
+	}
+
+	switch {
+	case !pc.playing && pc.credits > 0:
+		pc.credits--
+		pc.playing = true
+		pc.timerEnd = nes.cycles + pc.playTimeCycles()
+		return true
+	case pc.playing && nes.cycles >= pc.timerEnd:
+		if pc.credits > 0 {
+			pc.credits--
+			pc.timerEnd += pc.playTimeCycles()
+		} else {
+			pc.playing = false
+			return true
+		}
+	}
+
+	return false
+}
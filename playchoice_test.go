@@ -0,0 +1,204 @@
+package nes
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+// newPlayChoiceTestData builds a PlayChoice-10 game with the given INST-ROM
+// and PROM, whose PRG ROM loops forever in $8000.
+func newPlayChoiceTestData(instROM []uint8, prom []uint8) []uint8 {
+	header := make([]uint8, GameHeaderSize)
+	copy(header, NESMagicNumber)
+	header[4] = 1
+	header[7] = 0x02
+
+	prg := make([]uint8, PRGBankSize)
+	// JMP $8000
+	copy(prg, []uint8{0x4C, 0x00, 0x80})
+	// reset vector
+	prg[0x3FFC] = 0x00
+	prg[0x3FFD] = 0x80
+
+	data := append(header, prg...)
+	data = append(data, instROM...)
+
+	return append(data, prom...)
+}
+
+// newTestINSTROM creates an INST-ROM whose hint screen has the tiles 0-255
+// repeated.
+func newTestINSTROM() []uint8 {
+	rom := make([]uint8, PlayChoiceSize)
+	for i := range rom[:PlayChoiceHintScreenSize] {
+		rom[i] = uint8(i)
+	}
+
+	return rom
+}
+
+func TestLoadGame_PlayChoice(t *testing.T) {
+	prom := make([]uint8, PlayChoicePROMSize)
+	for i := range prom {
+		prom[i] = uint8(i)
+	}
+
+	game, err := LoadGame(bytes.NewReader(newPlayChoiceTestData(newTestINSTROM(), prom)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(game.Warnings) != 0 {
+		t.Errorf("unexpected warnings; got=%q", game.Warnings)
+	}
+	if screen := game.PlayChoiceINSTROM.HintScreen(); len(screen) != PlayChoiceHintScreenSize || screen[0x21] != 0x21 {
+		t.Errorf("unexpected hint screen; got=% X", screen)
+	}
+	if data := game.PlayChoicePROM.Data(); !bytes.Equal(data, prom[:16]) {
+		t.Errorf("unexpected PROM data; got=% X, want=% X", data, prom[:16])
+	}
+	if counterOut := game.PlayChoicePROM.CounterOut(); !bytes.Equal(counterOut, prom[16:]) {
+		t.Errorf("unexpected PROM counter output; got=% X, want=% X", counterOut, prom[16:])
+	}
+}
+
+func TestLoadGame_PlayChoiceMissingPROM(t *testing.T) {
+	game, err := LoadGame(bytes.NewReader(newPlayChoiceTestData(newTestINSTROM(), nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(game.Warnings) != 1 {
+		t.Errorf("unexpected warnings; got=%q, want 1 warning", game.Warnings)
+	}
+	if prom := game.PlayChoicePROM.Data(); prom != nil {
+		t.Errorf("unexpected PROM data; got=% X, want none", prom)
+	}
+
+	_, err = LoadGame(bytes.NewReader(newPlayChoiceTestData(newTestINSTROM(), make([]uint8, 10))))
+	if _, ok := err.(TruncatedROMError); !ok {
+		t.Errorf("unexpected error with a truncated PROM; got=%v, want=%T", err, TruncatedROMError{})
+	}
+}
+
+func TestNES_PlayChoice(t *testing.T) {
+	game, err := LoadGame(bytes.NewReader(newPlayChoiceTestData(newTestINSTROM(), nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if pc := newPlayChoice(*game, 0); pc.PlayTime != PlayChoiceDefaultPlayTime {
+		t.Errorf("unexpected default play time; got=%v, want=%v", pc.PlayTime, PlayChoiceDefaultPlayTime)
+	}
+
+	system := NES{PlayChoiceTime: time.Second}
+	if _, err := system.PowerOn(*game); err != nil {
+		t.Fatal(err)
+	}
+
+	pc := system.PlayChoice
+	if pc == nil {
+		t.Fatal("the PlayChoice-10 timer should be set")
+	}
+
+	step := func() {
+		if _, err := system.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// the demo runs for free
+	for system.Cycles() < 2*CPUClockRate {
+		step()
+	}
+
+	if !pc.IsAttractMode() {
+		t.Fatal("the game should start in attract mode")
+	}
+
+	system.InsertCoin()
+	system.InsertCoin()
+	step()
+
+	if pc.IsAttractMode() || pc.Credits() != 1 {
+		t.Fatalf("a coin should start the game; got attract mode=%v, credits=%v", pc.IsAttractMode(), pc.Credits())
+	}
+
+	start := system.Cycles()
+
+	for system.Cycles() < start+CPUClockRate {
+		step()
+	}
+
+	if pc.IsAttractMode() || pc.Credits() != 0 {
+		t.Fatalf("the second credit should be spent; got attract mode=%v, credits=%v", pc.IsAttractMode(), pc.Credits())
+	}
+
+	for system.Cycles() < start+2*CPUClockRate {
+		step()
+	}
+
+	if !pc.IsAttractMode() {
+		t.Error("the game should go back to attract mode when the credits end")
+	}
+}
+
+func TestNES_PlayChoiceReset(t *testing.T) {
+	game, err := LoadGame(bytes.NewReader(newPlayChoiceTestData(newTestINSTROM(), nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	system := NES{CycleAccurate: true, PlayChoiceTime: time.Second}
+	if _, err := system.PowerOn(*game); err != nil {
+		t.Fatal(err)
+	}
+
+	pc := system.PlayChoice
+	resets := 0
+
+	step := func() {
+		attract := pc.IsAttractMode()
+		frame := system.PPU.FrameCount()
+
+		cycles, err := system.Step()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if system.stepAccesses != cycles {
+			t.Fatalf("every cycle of the step should access the bus; got=%v accesses, want=%v", system.stepAccesses, cycles)
+		}
+		if system.PPU.FrameCount() < frame {
+			t.Fatalf("the frame count should not restart; got=%v, was=%v", system.PPU.FrameCount(), frame)
+		}
+
+		if pc.IsAttractMode() != attract {
+			resets++
+
+			if cycles != ResetCycles {
+				t.Errorf("the step should only reset the system; got=%v cycles, want=%v", cycles, ResetCycles)
+			}
+		}
+	}
+
+	system.InsertCoin()
+	step()
+
+	start := system.Cycles()
+
+	for system.Cycles() < start+2*CPUClockRate {
+		step()
+	}
+
+	if !pc.IsAttractMode() {
+		t.Error("the game should go back to attract mode when the credit ends")
+	}
+	if resets != 2 {
+		t.Errorf("the game should be reset when the credit starts and when it ends; got=%v resets", resets)
+	}
+	if frames := system.PPU.FrameCount(); frames < 2*60 {
+		t.Errorf("the frames should be counted across the resets; got=%v", frames)
+	}
+}
@@ -0,0 +1,169 @@
+// Package ppu implements the Picture Processing Unit of the NES (RP2C02),
+// which is controlled by the CPU through 8 memory-mapped registers and
+// draws the picture from its own memory bus.
+package ppu
+
+import "image"
+
+// timing (NTSC)
+const (
+	// the PPU is clocked 3 times for each CPU cycle
+	DotsPerCPUCycle   = 3
+	DotsPerScanline   = 341
+	ScanlinesPerFrame = 262
+
+	VisibleScanlines  = 240
+	VBlankScanline    = 241
+	PreRenderScanline = 261
+)
+
+// PPU memory map
+const (
+	PatternTablesStart = 0x0000
+	PatternTablesEnd   = 0x1FFF
+
+	NametablesStart = 0x2000
+	NametablesEnd   = 0x3EFF
+
+	PaletteStart = 0x3F00
+	PaletteEnd   = 0x3FFF
+	PaletteSize  = 0x20
+
+	AddressMask = 0x3FFF
+)
+
+// OAMSize is the size of the object attribute memory, which holds 64
+// sprites of 4 bytes.
+const OAMSize = 256
+
+// Bus is the PPU memory bus, where the pattern tables and the nametables
+// are. The palette is inside the PPU, so its addresses aren't accessed
+// through it.
+type Bus interface {
+	ReadByte(address uint16) uint8
+	WriteByte(address uint16, value uint8)
+}
+
+// PPU is the RP2C02. Its position in the frame advances one dot each time
+// Clock is called.
+type PPU struct {
+	Bus Bus
+	OAM [OAMSize]uint8
+
+	control uint8
+	mask    uint8
+	status  uint8
+	oamAddr uint8
+
+	// current VRAM address (15 bits), which is also the scroll position
+	// while rendering
+	v uint16
+	// temporary VRAM address, the scroll position of the top left corner
+	t uint16
+	// fine X scroll (3 bits)
+	x uint8
+	// write toggle shared by PPUSCROLL and PPUADDR: set after the first
+	// write
+	w bool
+
+	// PPUDATA reads return the value read before, except for the palette
+	readBuffer uint8
+	// the internal data bus of the registers holds the last value written
+	// or read, which is returned by the write-only registers
+	latch uint8
+
+	palette [PaletteSize]uint8
+
+	dot      uint16
+	scanline uint16
+	frame    uint64
+
+	// sprites drawn in the current scanline
+	sprites     [maxSpritesPerScanline]sprite
+	spriteCount int
+	background  background
+
+	// the picture being drawn and the last one finished
+	back  *image.RGBA
+	front *image.RGBA
+}
+
+func New(bus Bus) *PPU {
+	return &PPU{
+		Bus:   bus,
+		back:  image.NewRGBA(image.Rect(0, 0, Width, Height)),
+		front: image.NewRGBA(image.Rect(0, 0, Width, Height)),
+	}
+}
+
+// Reset puts the PPU in the state it has after the reset signal: the
+// registers written by the CPU are cleared and the frame starts over. The
+// VBlank flag, OAM, the palette and the frame count are kept.
+func (ppu *PPU) Reset() {
+	ppu.control = 0x00
+	ppu.mask = 0x00
+	ppu.t = 0x0000
+	ppu.x = 0
+	ppu.w = false
+	ppu.readBuffer = 0x00
+	ppu.dot = 0
+	ppu.scanline = 0
+}
+
+// Position returns the dot (0-340) and the scanline (0-261) which will be
+// drawn next. The scanlines 0-239 are visible, VBlank starts in 241 and 261
+// is the pre-render scanline.
+func (ppu *PPU) Position() (dot uint16, scanline uint16) {
+	return ppu.dot, ppu.scanline
+}
+
+// FrameCount returns the number of frames started since the PPU was created.
+func (ppu *PPU) FrameCount() uint64 {
+	return ppu.frame
+}
+
+// IsRendering checks whether the background or the sprites are enabled.
+func (ppu *PPU) IsRendering() bool {
+	return ppu.mask&(MaskBackground|MaskSprites) != 0x00
+}
+
+// NMI returns the state of the /NMI output, which is asserted during VBlank
+// when it's enabled in PPUCTRL.
+func (ppu *PPU) NMI() bool {
+	return ppu.status&StatusVBlank != 0x00 && ppu.control&ControlNMI != 0x00
+}
+
+// Clock advances the PPU by one dot.
+func (ppu *PPU) Clock() {
+	if ppu.scanline < VisibleScanlines || ppu.scanline == PreRenderScanline {
+		ppu.render()
+	}
+
+	switch {
+	case ppu.scanline == VBlankScanline && ppu.dot == 1:
+		ppu.status |= StatusVBlank
+		ppu.front, ppu.back = ppu.back, ppu.front
+	case ppu.scanline == PreRenderScanline && ppu.dot == 1:
+		ppu.status &^= StatusVBlank | StatusSprite0Hit | StatusSpriteOverflow
+	}
+
+	ppu.dot++
+
+	// the last dot of the pre-render scanline is skipped in the odd frames
+	// while rendering
+	if ppu.scanline == PreRenderScanline && ppu.dot == DotsPerScanline-1 && ppu.frame%2 == 1 && ppu.IsRendering() {
+		ppu.dot++
+	}
+
+	if ppu.dot < DotsPerScanline {
+		return
+	}
+
+	ppu.dot = 0
+	ppu.scanline++
+
+	if ppu.scanline == ScanlinesPerFrame {
+		ppu.scanline = 0
+		ppu.frame++
+	}
+}
@@ -0,0 +1,232 @@
+package ppu
+
+import "testing"
+
+// testBus is a flat PPU memory bus.
+type testBus [0x4000]uint8
+
+func (bus *testBus) ReadByte(address uint16) uint8 {
+	return bus[address&AddressMask]
+}
+
+func (bus *testBus) WriteByte(address uint16, value uint8) {
+	bus[address&AddressMask] = value
+}
+
+// clockUntil clocks the PPU until it reaches dot of scanline.
+func clockUntil(t *testing.T, ppu *PPU, dot uint16, scanline uint16) {
+	for i := 0; i < 2*DotsPerScanline*ScanlinesPerFrame; i++ {
+		if d, s := ppu.Position(); d == dot && s == scanline {
+			return
+		}
+
+		ppu.Clock()
+	}
+
+	t.Fatalf("the PPU never reached dot %v of scanline %v", dot, scanline)
+}
+
+func TestPPU_Data(t *testing.T) {
+	bus := new(testBus)
+	ppu := New(bus)
+
+	bus[0x2345] = 0x12
+	bus[0x2346] = 0x34
+
+	ppu.WriteRegister(AddressRegister, 0x23)
+	ppu.WriteRegister(AddressRegister, 0x45)
+
+	if value := ppu.ReadRegister(DataRegister); value != 0x00 {
+		t.Errorf("the first read should return the buffer; got=%02X, want=%02X", value, 0x00)
+	}
+	if value := ppu.ReadRegister(DataRegister); value != 0x12 {
+		t.Errorf("unexpected buffered value; got=%02X, want=%02X", value, 0x12)
+	}
+
+	ppu.WriteRegister(ControlRegister, ControlIncrement32)
+	ppu.WriteRegister(DataRegister, 0x56)
+	ppu.WriteRegister(DataRegister, 0x78)
+
+	if value := bus[0x2347]; value != 0x56 {
+		t.Errorf("unexpected value written to PPUDATA; got=%02X, want=%02X", value, 0x56)
+	}
+	if value := bus[0x2367]; value != 0x78 {
+		t.Errorf("unexpected value written to PPUDATA after incrementing by 32; got=%02X, want=%02X", value, 0x78)
+	}
+}
+
+func TestPPU_Palette(t *testing.T) {
+	bus := new(testBus)
+	ppu := New(bus)
+
+	bus[0x2F05] = 0x9A
+
+	ppu.WriteRegister(AddressRegister, 0x3F)
+	ppu.WriteRegister(AddressRegister, 0x05)
+	ppu.WriteRegister(DataRegister, 0xFF)
+
+	if value := bus[0x3F05]; value != 0x00 {
+		t.Errorf("the palette should not be written to the bus; got=%02X, want=%02X", value, 0x00)
+	}
+
+	ppu.WriteRegister(AddressRegister, 0x3F)
+	ppu.WriteRegister(AddressRegister, 0x05)
+
+	// the palette has only 6 bits; the others come from the last write
+	if value := ppu.ReadRegister(DataRegister); value != 0x3F|0x05&0xC0 {
+		t.Errorf("the palette should be read without the buffer; got=%02X, want=%02X", value, 0x3F)
+	}
+	if value := ppu.readBuffer; value != 0x9A {
+		t.Errorf("the buffer should get the nametable under the palette; got=%02X, want=%02X", value, 0x9A)
+	}
+}
+
+func TestPPU_Scroll(t *testing.T) {
+	ppu := New(new(testBus))
+
+	ppu.WriteRegister(ControlRegister, 0x02)
+	// X = 125, Y = 94
+	ppu.WriteRegister(ScrollRegister, 0x7D)
+	ppu.WriteRegister(ScrollRegister, 0x5E)
+
+	if want := uint16(0x696F); ppu.t != want {
+		t.Errorf("unexpected temporary address after PPUSCROLL; got=%04X, want=%04X", ppu.t, want)
+	}
+	if ppu.x != 0x05 {
+		t.Errorf("unexpected fine X scroll; got=%v, want=%v", ppu.x, 0x05)
+	}
+
+	// PPUSTATUS resets the write toggle
+	ppu.WriteRegister(AddressRegister, 0x12)
+	ppu.ReadRegister(StatusRegister)
+	ppu.WriteRegister(AddressRegister, 0x3D)
+	ppu.WriteRegister(AddressRegister, 0xF0)
+
+	if ppu.v != 0x3DF0 {
+		t.Errorf("unexpected address after PPUADDR; got=%04X, want=%04X", ppu.v, 0x3DF0)
+	}
+}
+
+func TestPPU_OAM(t *testing.T) {
+	ppu := New(new(testBus))
+
+	ppu.WriteRegister(OAMAddressRegister, 0xFE)
+	ppu.WriteRegister(OAMDataRegister, 0xFF)
+	ppu.WriteRegister(OAMDataRegister, 0xFF)
+	ppu.WriteRegister(OAMDataRegister, 0xFF)
+
+	if value := ppu.OAM[0x00]; value != 0xFF {
+		t.Errorf("OAMADDR should wrap around; got=%02X, want=%02X", value, 0xFF)
+	}
+
+	ppu.WriteRegister(OAMAddressRegister, 0xFE)
+
+	// sprite attribute
+	if value := ppu.ReadRegister(OAMDataRegister); value != 0xE3 {
+		t.Errorf("unexpected sprite attribute read; got=%02X, want=%02X", value, 0xE3)
+	}
+	if value := ppu.ReadRegister(OAMDataRegister); value != 0xE3 {
+		t.Errorf("OAMDATA reads should not increment OAMADDR; got=%02X, want=%02X", value, 0xE3)
+	}
+}
+
+func TestPPU_VBlank(t *testing.T) {
+	ppu := New(new(testBus))
+
+	ppu.WriteRegister(ControlRegister, ControlNMI)
+	clockUntil(t, ppu, 1, VBlankScanline)
+
+	if ppu.NMI() {
+		t.Fatal("NMI should not be asserted before VBlank")
+	}
+
+	ppu.Clock()
+
+	if !ppu.NMI() {
+		t.Fatal("NMI should be asserted in VBlank")
+	}
+	if status := ppu.PeekRegister(StatusRegister); status&StatusVBlank == 0x00 {
+		t.Errorf("peeking should not clear VBlank; got=%02X", status)
+	}
+	if status := ppu.ReadRegister(StatusRegister); status&StatusVBlank == 0x00 {
+		t.Errorf("VBlank should be set; got=%02X", status)
+	}
+	if status := ppu.ReadRegister(StatusRegister); status&StatusVBlank != 0x00 {
+		t.Errorf("VBlank should be cleared after reading PPUSTATUS; got=%02X", status)
+	}
+	if ppu.NMI() {
+		t.Error("NMI should be released after VBlank is cleared")
+	}
+
+	// next frame
+	clockUntil(t, ppu, 0, 0)
+	clockUntil(t, ppu, 5, VBlankScanline)
+	ppu.WriteRegister(ControlRegister, 0x00)
+
+	if ppu.NMI() {
+		t.Error("NMI should be released when it's disabled")
+	}
+
+	ppu.WriteRegister(ControlRegister, ControlNMI)
+
+	if !ppu.NMI() {
+		t.Error("NMI should be asserted when it's enabled during VBlank")
+	}
+
+	clockUntil(t, ppu, 2, PreRenderScanline)
+
+	if ppu.NMI() {
+		t.Error("VBlank should end in the pre-render scanline")
+	}
+}
+
+func TestPPU_OddFrame(t *testing.T) {
+	ppu := New(new(testBus))
+
+	clockUntil(t, ppu, 0, 0)
+	ppu.WriteRegister(MaskRegister, MaskBackground)
+
+	dots := 0
+	for frame := ppu.FrameCount(); ppu.FrameCount() == frame; dots++ {
+		ppu.Clock()
+	}
+
+	if want := DotsPerScanline * ScanlinesPerFrame; dots != want {
+		t.Errorf("unexpected dots in an even frame; got=%v, want=%v", dots, want)
+	}
+
+	dots = 0
+	for frame := ppu.FrameCount(); ppu.FrameCount() == frame; dots++ {
+		ppu.Clock()
+	}
+
+	if want := DotsPerScanline*ScanlinesPerFrame - 1; dots != want {
+		t.Errorf("unexpected dots in an odd frame while rendering; got=%v, want=%v", dots, want)
+	}
+}
+
+func TestPPU_PaletteMirrors(t *testing.T) {
+	ppu := New(new(testBus))
+
+	for _, index := range []uint8{0x10, 0x14, 0x18, 0x1C} {
+		writePalette(ppu, index, index)
+
+		ppu.WriteRegister(AddressRegister, 0x3F)
+		ppu.WriteRegister(AddressRegister, index&^0x10)
+
+		if value := ppu.ReadRegister(DataRegister) & 0x3F; value != index {
+			t.Errorf("write to $3F%02X should be read from $3F%02X; got=%02X, want=%02X", index, index&^0x10, value, index)
+		}
+	}
+
+	writePalette(ppu, 0x11, 0x2A)
+	writePalette(ppu, 0x01, 0x15)
+
+	// $3F20-$3FFF mirrors $3F00-$3F1F
+	ppu.WriteRegister(AddressRegister, 0x3F)
+	ppu.WriteRegister(AddressRegister, 0xF1)
+
+	if value := ppu.ReadRegister(DataRegister) & 0x3F; value != 0x2A {
+		t.Errorf("unexpected value read from $3FF1; got=%02X, want=%02X", value, 0x2A)
+	}
+}
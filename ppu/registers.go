@@ -0,0 +1,183 @@
+package ppu
+
+// registers, as offsets from $2000 in the CPU memory map
+const (
+	ControlRegister    = 0x0 // PPUCTRL
+	MaskRegister       = 0x1 // PPUMASK
+	StatusRegister     = 0x2 // PPUSTATUS
+	OAMAddressRegister = 0x3 // OAMADDR
+	OAMDataRegister    = 0x4 // OAMDATA
+	ScrollRegister     = 0x5 // PPUSCROLL
+	AddressRegister    = 0x6 // PPUADDR
+	DataRegister       = 0x7 // PPUDATA
+
+	RegisterCount = 8
+)
+
+// PPUCTRL
+const (
+	ControlNametable              = 0x03
+	ControlIncrement32            = 0x04
+	ControlSpritePatternTable     = 0x08
+	ControlBackgroundPatternTable = 0x10
+	ControlSpriteSize16           = 0x20
+	ControlNMI                    = 0x80
+)
+
+// PPUMASK
+const (
+	MaskGrayscale      = 0x01
+	MaskBackgroundLeft = 0x02
+	MaskSpritesLeft    = 0x04
+	MaskBackground     = 0x08
+	MaskSprites        = 0x10
+	MaskEmphasis       = 0xE0
+)
+
+// PPUSTATUS; the other bits aren't driven
+const (
+	StatusSpriteOverflow = 0x20
+	StatusSprite0Hit     = 0x40
+	StatusVBlank         = 0x80
+)
+
+// ReadRegister reads the register with its side effects: PPUSTATUS clears
+// VBlank and the write toggle, and PPUDATA increments the VRAM address.
+// The write-only registers return the last value on the PPU data bus.
+func (ppu *PPU) ReadRegister(register uint16) uint8 {
+	switch register % RegisterCount {
+	case StatusRegister:
+		ppu.latch = ppu.PeekRegister(register)
+		ppu.status &^= StatusVBlank
+		ppu.w = false
+	case OAMDataRegister:
+		ppu.latch = ppu.PeekRegister(register)
+	case DataRegister:
+		ppu.latch = ppu.PeekRegister(register)
+		address := ppu.v & AddressMask
+
+		if address >= PaletteStart {
+			// the buffer gets the nametable "under" the palette
+			ppu.readBuffer = ppu.Bus.ReadByte(address - 0x1000)
+		} else {
+			ppu.readBuffer = ppu.Bus.ReadByte(address)
+		}
+
+		ppu.incrementAddress()
+	}
+
+	return ppu.latch
+}
+
+// PeekRegister reads the register like ReadRegister, without the side
+// effects.
+func (ppu *PPU) PeekRegister(register uint16) uint8 {
+	switch register % RegisterCount {
+	case StatusRegister:
+		return ppu.status | ppu.latch&^(StatusVBlank|StatusSprite0Hit|StatusSpriteOverflow)
+	case OAMDataRegister:
+		value := ppu.OAM[ppu.oamAddr]
+		if ppu.oamAddr&0x03 == 0x02 {
+			// the sprite attributes don't have bits 2-4
+			value &= 0xE3
+		}
+
+		return value
+	case DataRegister:
+		if address := ppu.v & AddressMask; address >= PaletteStart {
+			// the palette has only 6 bits
+			return ppu.readPalette(address) | ppu.latch&0xC0
+		}
+
+		return ppu.readBuffer
+	default:
+		return ppu.latch
+	}
+}
+
+// WriteRegister writes value to the register. The read-only PPUSTATUS only
+// keeps the value on the PPU data bus.
+func (ppu *PPU) WriteRegister(register uint16, value uint8) {
+	ppu.latch = value
+
+	switch register % RegisterCount {
+	case ControlRegister:
+		ppu.control = value
+		ppu.t = ppu.t&0xF3FF | uint16(value&ControlNametable)<<10
+	case MaskRegister:
+		ppu.mask = value
+	case OAMAddressRegister:
+		ppu.oamAddr = value
+	case OAMDataRegister:
+		ppu.OAM[ppu.oamAddr] = value
+		ppu.oamAddr++
+	case ScrollRegister:
+		if !ppu.w {
+			// coarse X and fine X
+			ppu.t = ppu.t&0xFFE0 | uint16(value>>3)
+			ppu.x = value & 0x07
+		} else {
+			// coarse Y and fine Y
+			ppu.t = ppu.t&0x8C1F | uint16(value&0x07)<<12 | uint16(value&0xF8)<<2
+		}
+
+		ppu.w = !ppu.w
+	case AddressRegister:
+		if !ppu.w {
+			// high byte; bit 14 is cleared
+			ppu.t = ppu.t&0x00FF | uint16(value&0x3F)<<8
+		} else {
+			ppu.t = ppu.t&0xFF00 | uint16(value)
+			ppu.v = ppu.t
+		}
+
+		ppu.w = !ppu.w
+	case DataRegister:
+		if address := ppu.v & AddressMask; address >= PaletteStart {
+			ppu.writePalette(address, value)
+		} else {
+			ppu.Bus.WriteByte(address, value)
+		}
+
+		ppu.incrementAddress()
+	}
+}
+
+// incrementAddress moves the VRAM address to the next byte, or to the next
+// row of the nametable, after PPUDATA is accessed. While rendering, the
+// coarse X and Y increments of the scroll position happen instead.
+func (ppu *PPU) incrementAddress() {
+	if ppu.IsRendering() && (ppu.scanline < VisibleScanlines || ppu.scanline == PreRenderScanline) {
+		ppu.incrementX()
+		ppu.incrementY()
+		return
+	}
+
+	if ppu.control&ControlIncrement32 != 0x00 {
+		ppu.v += 32
+	} else {
+		ppu.v++
+	}
+
+	ppu.v &= 0x7FFF
+}
+
+// paletteIndex returns the entry of the palette RAM in address. The color 0
+// of the sprite palettes ($3F10, $3F14, $3F18 and $3F1C) is the same as the
+// one of the background palettes.
+func paletteIndex(address uint16) uint16 {
+	index := address % PaletteSize
+	if index&0x13 == 0x10 {
+		index &^= 0x10
+	}
+
+	return index
+}
+
+func (ppu *PPU) readPalette(address uint16) uint8 {
+	return ppu.palette[paletteIndex(address)] & 0x3F
+}
+
+func (ppu *PPU) writePalette(address uint16, value uint8) {
+	ppu.palette[paletteIndex(address)] = value & 0x3F
+}
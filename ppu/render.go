@@ -0,0 +1,445 @@
+package ppu
+
+import "image"
+
+// size of the picture, in pixels
+const (
+	Width  = 256
+	Height = VisibleScanlines
+)
+
+const (
+	// the background tiles are fetched in slots of 8 dots, during the visible
+	// dots and, for the first 2 tiles of the next scanline, after the sprites
+	backgroundFetchEndDot   = Width
+	backgroundPrefetchStart = 321
+	backgroundPrefetchEnd   = 336
+
+	// the sprites for the next scanline are evaluated after the visible
+	// dots, then their patterns are fetched in 8 slots of 8 dots
+	spriteEvaluationDot = Width + 1
+	spriteFetchEndDot   = 320
+
+	// the horizontal scroll position is copied from t to v after each
+	// scanline, and the vertical one during the pre-render scanline
+	copyHorizontalDot    = Width + 1
+	copyVerticalStartDot = 280
+	copyVerticalEndDot   = 304
+
+	// the nametable is read twice more at the end of the scanline
+	unusedFetchDot1 = 337
+	unusedFetchDot2 = 339
+
+	maxSpritesPerScanline = 8
+
+	spriteAttributePalette = 0x03
+	spriteAttributeBehind  = 0x20
+	spriteAttributeFlipH   = 0x40
+	spriteAttributeFlipV   = 0x80
+	spritePaletteOffset    = 0x10
+	spriteDummyTile        = 0xFF
+	attributeTableOffset   = 0x03C0
+	nametableSize          = 0x0400
+	patternTableHalf       = 0x1000
+	patternTilePlaneSize   = 8
+)
+
+// parts of the VRAM address (v and t) while rendering: yyy NN YYYYY XXXXX
+const (
+	coarseXMask    = 0x001F
+	coarseYMask    = 0x03E0
+	nametableXMask = 0x0400
+	nametableYMask = 0x0800
+	fineYMask      = 0x7000
+
+	coarseYShift = 5
+	fineYShift   = 12
+
+	// the last coarse X, and the last row of tiles of a nametable (the next
+	// ones are the attribute table)
+	lastCoarseX   = 31
+	lastTileRow   = 29
+	lastCoarseRow = 31
+
+	horizontalMask = nametableXMask | coarseXMask
+	verticalMask   = fineYMask | nametableYMask | coarseYMask
+)
+
+// sprite is one of the sprites drawn in the current scanline.
+type sprite struct {
+	x          uint8
+	attributes uint8
+	tile       uint8
+	// row of the sprite in the scanline
+	row uint8
+	// pattern of the row, already flipped
+	low  uint8
+	high uint8
+	// sprite 0 is the first one in OAM, which sets the sprite 0 hit flag
+	zero bool
+}
+
+// background is the background pipeline: the bytes of the next tile are
+// read in the fetch slots, then loaded into the low half of the shift
+// registers, which move one bit each dot.
+type background struct {
+	tile      uint8
+	attribute uint8
+	low       uint8
+	high      uint8
+
+	patternLow    uint16
+	patternHigh   uint16
+	attributeLow  uint16
+	attributeHigh uint16
+}
+
+// Frame returns a copy of the last picture drawn completely, which is
+// replaced when the next one is finished (at the start of VBlank). The copy
+// isn't changed by the next frames, but Frame itself must not be called
+// while the PPU is clocked by another goroutine.
+func (ppu *PPU) Frame() *image.RGBA {
+	frame := image.NewRGBA(ppu.front.Rect)
+	copy(frame.Pix, ppu.front.Pix)
+
+	return frame
+}
+
+// render does the work of the current dot of a visible or pre-render
+// scanline.
+func (ppu *PPU) render() {
+	dot := ppu.dot
+	visible := ppu.scanline < VisibleScanlines
+
+	if visible && dot >= 1 && dot <= Width {
+		ppu.drawPixel(dot - 1)
+	}
+
+	if !ppu.IsRendering() {
+		return
+	}
+
+	if (dot >= 1 && dot <= backgroundFetchEndDot) || (dot >= backgroundPrefetchStart && dot <= backgroundPrefetchEnd) {
+		ppu.shiftBackground()
+		ppu.fetchBackground(dot % 8)
+
+		if dot%8 == 0 {
+			ppu.loadBackground()
+			ppu.incrementX()
+		}
+	}
+
+	switch {
+	case dot == backgroundFetchEndDot:
+		ppu.incrementY()
+	case dot == copyHorizontalDot:
+		ppu.v = ppu.v&^horizontalMask | ppu.t&horizontalMask
+	case dot == unusedFetchDot1 || dot == unusedFetchDot2:
+		ppu.Bus.ReadByte(NametablesStart | ppu.v&0x0FFF)
+	}
+
+	if ppu.scanline == PreRenderScanline && dot >= copyVerticalStartDot && dot <= copyVerticalEndDot {
+		ppu.v = ppu.v&^verticalMask | ppu.t&verticalMask
+	}
+
+	if dot == spriteEvaluationDot {
+		if visible {
+			ppu.evaluateSprites()
+		} else {
+			// there are no sprites in the first scanline
+			ppu.spriteCount = 0
+		}
+	}
+
+	if dot >= spriteEvaluationDot && dot <= spriteFetchEndDot && (dot-spriteEvaluationDot)%8 == 0 {
+		ppu.fetchSprite(int(dot-spriteEvaluationDot) / 8)
+	}
+}
+
+// drawPixel draws the pixel in column x of the current scanline, combining
+// the background and the sprites.
+func (ppu *PPU) drawPixel(x uint16) {
+	var address uint8
+
+	if !ppu.IsRendering() {
+		// the backdrop, unless the VRAM address points to the palette
+		if v := ppu.v & AddressMask; v >= PaletteStart {
+			address = uint8(v % PaletteSize)
+		}
+	} else {
+		background := ppu.backgroundPixel(x)
+		sprite, behind, zero := ppu.spritePixel(x)
+
+		switch {
+		case sprite&0x03 == 0x00:
+			address = background
+		case background&0x03 == 0x00:
+			address = sprite
+		default:
+			if zero && x != Width-1 {
+				ppu.status |= StatusSprite0Hit
+			}
+
+			if behind {
+				address = background
+			} else {
+				address = sprite
+			}
+		}
+
+		if address&0x03 == 0x00 {
+			address = 0x00
+		}
+	}
+
+	value := ppu.readPalette(PaletteStart + uint16(address))
+	if ppu.mask&MaskGrayscale != 0x00 {
+		value &= 0x30
+	}
+
+	c := MasterPalette[value]
+	if emphasis := ppu.mask & MaskEmphasis; emphasis != 0x00 {
+		c = emphasize(c, emphasis)
+	}
+
+	i := ppu.back.PixOffset(int(x), int(ppu.scanline))
+	ppu.back.Pix[i] = c.R
+	ppu.back.Pix[i+1] = c.G
+	ppu.back.Pix[i+2] = c.B
+	ppu.back.Pix[i+3] = c.A
+}
+
+// backgroundPixel returns the palette address (0-15) of the background in
+// column x of the current scanline; the pixel is transparent when its 2 low
+// bits are 0. Fine X selects the bit of the shift registers.
+func (ppu *PPU) backgroundPixel(x uint16) uint8 {
+	if ppu.mask&MaskBackground == 0x00 || (x < 8 && ppu.mask&MaskBackgroundLeft == 0x00) {
+		return 0x00
+	}
+
+	bg := &ppu.background
+	bit := 15 - ppu.x
+	color := uint8(bg.patternLow>>bit&0x01 | (bg.patternHigh>>bit&0x01)<<1)
+	palette := uint8(bg.attributeLow>>bit&0x01 | (bg.attributeHigh>>bit&0x01)<<1)
+
+	return palette<<2 | color
+}
+
+// fetchBackground reads the byte of the next tile for the step of its fetch
+// slot: the nametable, the attribute table and both planes of the pattern.
+// Each read takes 2 dots; the byte is read in the second one.
+func (ppu *PPU) fetchBackground(step uint16) {
+	bg := &ppu.background
+	v := ppu.v
+
+	switch step {
+	case 2:
+		bg.tile = ppu.Bus.ReadByte(NametablesStart | v&0x0FFF)
+	case 4:
+		// one attribute byte for each area of 4x4 tiles
+		address := NametablesStart + attributeTableOffset | v&(nametableYMask|nametableXMask) | v>>4&0x38 | v>>2&0x07
+		attribute := ppu.Bus.ReadByte(address)
+
+		// which has the palettes of 4 areas of 2x2 tiles
+		shift := v>>4&0x04 | v&0x02
+		bg.attribute = attribute >> shift & 0x03
+	case 6:
+		bg.low = ppu.Bus.ReadByte(ppu.backgroundPatternAddress())
+	case 0:
+		bg.high = ppu.Bus.ReadByte(ppu.backgroundPatternAddress() + patternTilePlaneSize)
+	}
+}
+
+// backgroundPatternAddress returns the address of the low plane of the
+// pattern in the row of the next tile.
+func (ppu *PPU) backgroundPatternAddress() uint16 {
+	var table uint16
+	if ppu.control&ControlBackgroundPatternTable != 0x00 {
+		table = patternTableHalf
+	}
+
+	return table + uint16(ppu.background.tile)*16 + (ppu.v&fineYMask)>>fineYShift
+}
+
+// loadBackground loads the tile fetched into the low half of the shift
+// registers. The attribute is the same for its 8 pixels.
+func (ppu *PPU) loadBackground() {
+	bg := &ppu.background
+
+	bg.patternLow = bg.patternLow&0xFF00 | uint16(bg.low)
+	bg.patternHigh = bg.patternHigh&0xFF00 | uint16(bg.high)
+	bg.attributeLow = bg.attributeLow&0xFF00 | 0x00FF*uint16(bg.attribute&0x01)
+	bg.attributeHigh = bg.attributeHigh&0xFF00 | 0x00FF*uint16(bg.attribute>>1)
+}
+
+func (ppu *PPU) shiftBackground() {
+	bg := &ppu.background
+
+	bg.patternLow <<= 1
+	bg.patternHigh <<= 1
+	bg.attributeLow <<= 1
+	bg.attributeHigh <<= 1
+}
+
+// incrementX moves v to the next tile, wrapping to the next horizontal
+// nametable.
+func (ppu *PPU) incrementX() {
+	if ppu.v&coarseXMask == lastCoarseX {
+		ppu.v &^= coarseXMask
+		ppu.v ^= nametableXMask
+	} else {
+		ppu.v++
+	}
+}
+
+// incrementY moves v to the next row of pixels, wrapping to the next
+// vertical nametable after the row 29. The rows 30 and 31 (set by PPUSCROLL
+// or PPUADDR) read the attribute table as tiles, then wrap to the same
+// nametable.
+func (ppu *PPU) incrementY() {
+	if ppu.v&fineYMask != fineYMask {
+		ppu.v += 1 << fineYShift
+		return
+	}
+
+	ppu.v &^= fineYMask
+	y := (ppu.v & coarseYMask) >> coarseYShift
+
+	switch y {
+	case lastTileRow:
+		y = 0
+		ppu.v ^= nametableYMask
+	case lastCoarseRow:
+		y = 0
+	default:
+		y++
+	}
+
+	ppu.v = ppu.v&^coarseYMask | y<<coarseYShift
+}
+
+// spriteHeight returns the height of the sprites: 8 or 16 pixels.
+func (ppu *PPU) spriteHeight() uint16 {
+	if ppu.control&ControlSpriteSize16 != 0x00 {
+		return 16
+	}
+
+	return 8
+}
+
+// evaluateSprites finds the first 8 sprites in OAM which are drawn in the
+// next scanline. The sprite overflow flag is set when there are more.
+func (ppu *PPU) evaluateSprites() {
+	ppu.spriteCount = 0
+	height := ppu.spriteHeight()
+
+	for i := 0; i < OAMSize; i += 4 {
+		// the sprites are drawn one scanline below their Y
+		row := ppu.scanline - uint16(ppu.OAM[i])
+		if row >= height {
+			continue
+		}
+
+		if ppu.spriteCount == maxSpritesPerScanline {
+			ppu.status |= StatusSpriteOverflow
+			break
+		}
+
+		ppu.sprites[ppu.spriteCount] = sprite{
+			tile:       ppu.OAM[i+1],
+			attributes: ppu.OAM[i+2],
+			x:          ppu.OAM[i+3],
+			row:        uint8(row),
+			zero:       i == 0,
+		}
+		ppu.spriteCount++
+	}
+}
+
+// fetchSprite reads the pattern of the sprite in slot. The empty slots fetch
+// the tile 0xFF, whose pattern is discarded, as the mappers watching the PPU
+// address lines count on these reads.
+func (ppu *PPU) fetchSprite(slot int) {
+	s := &ppu.sprites[slot]
+	tile := s.tile
+	row := uint16(s.row)
+
+	if slot >= ppu.spriteCount {
+		tile = spriteDummyTile
+		row = 0
+	} else if s.attributes&spriteAttributeFlipV != 0x00 {
+		row = ppu.spriteHeight() - 1 - row
+	}
+
+	var table uint16
+
+	if ppu.spriteHeight() == 16 {
+		// the tile selects the pattern table and the top half
+		if tile&0x01 != 0x00 {
+			table = patternTableHalf
+		}
+
+		tile &= 0xFE
+		if row >= 8 {
+			tile++
+			row -= 8
+		}
+	} else if ppu.control&ControlSpritePatternTable != 0x00 {
+		table = patternTableHalf
+	}
+
+	address := table + uint16(tile)*16 + row
+	low := ppu.Bus.ReadByte(address)
+	high := ppu.Bus.ReadByte(address + patternTilePlaneSize)
+
+	if slot >= ppu.spriteCount {
+		return
+	}
+
+	if s.attributes&spriteAttributeFlipH != 0x00 {
+		low = reverseBits(low)
+		high = reverseBits(high)
+	}
+
+	s.low = low
+	s.high = high
+}
+
+// spritePixel returns the palette address (16-31) of the first opaque sprite
+// in column x of the current scanline, whether it's behind the background
+// and whether it's sprite 0. The pixel is transparent when the 2 low bits of
+// the address are 0.
+func (ppu *PPU) spritePixel(x uint16) (uint8, bool, bool) {
+	if ppu.mask&MaskSprites == 0x00 || (x < 8 && ppu.mask&MaskSpritesLeft == 0x00) {
+		return 0x00, false, false
+	}
+
+	for i := 0; i < ppu.spriteCount; i++ {
+		s := &ppu.sprites[i]
+
+		offset := x - uint16(s.x)
+		if offset >= 8 {
+			continue
+		}
+
+		bit := 7 - offset
+		color := s.low>>bit&0x01 | (s.high>>bit&0x01)<<1
+		if color == 0x00 {
+			continue
+		}
+
+		address := spritePaletteOffset | (s.attributes&spriteAttributePalette)<<2 | color
+
+		return address, s.attributes&spriteAttributeBehind != 0x00, s.zero
+	}
+
+	return 0x00, false, false
+}
+
+func reverseBits(b uint8) uint8 {
+	b = b&0xF0>>4 | b&0x0F<<4
+	b = b&0xCC>>2 | b&0x33<<2
+	b = b&0xAA>>1 | b&0x55<<1
+
+	return b
+}
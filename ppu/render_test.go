@@ -0,0 +1,293 @@
+package ppu
+
+import (
+	"image/color"
+	"testing"
+)
+
+// newRenderTestPPU creates a PPU whose nametables are filled with tile 1,
+// which has the color 1 in all of its pixels, and tile 2, which has the color
+// 2, is available for the sprites. The palette 0 has white as its color 1,
+// the palette 1 has red and the backdrop is black.
+func newRenderTestPPU() (*PPU, *testBus) {
+	bus := new(testBus)
+	ppu := New(bus)
+
+	for i := 0; i < 8; i++ {
+		// tile 1, low plane
+		bus[0x0010+i] = 0xFF
+		// tile 2, high plane
+		bus[0x0028+i] = 0xFF
+	}
+
+	for i := NametablesStart; i < NametablesStart+4*nametableSize; i++ {
+		if i%nametableSize < attributeTableOffset {
+			bus[i] = 0x01
+		}
+	}
+
+	writePalette(ppu, 0x00, 0x0F)
+	writePalette(ppu, 0x01, 0x30)
+	writePalette(ppu, 0x05, 0x16)
+	// sprite palette 1
+	writePalette(ppu, 0x16, 0x27)
+
+	return ppu, bus
+}
+
+func writePalette(ppu *PPU, index uint8, value uint8) {
+	ppu.WriteRegister(AddressRegister, 0x3F)
+	ppu.WriteRegister(AddressRegister, index)
+	ppu.WriteRegister(DataRegister, value)
+}
+
+// renderFrame draws a whole frame with the scroll position set to (x, y).
+func renderFrame(t *testing.T, ppu *PPU, x uint8, y uint8) {
+	ppu.WriteRegister(ControlRegister, ppu.control&^ControlNametable)
+	ppu.WriteRegister(ScrollRegister, x)
+	ppu.WriteRegister(ScrollRegister, y)
+
+	clockUntil(t, ppu, 0, PreRenderScanline)
+	clockUntil(t, ppu, 2, VBlankScanline)
+}
+
+func checkPixel(t *testing.T, ppu *PPU, x int, y int, want uint8) {
+	t.Helper()
+
+	if got := ppu.Frame().RGBAAt(x, y); got != MasterPalette[want] {
+		t.Errorf("unexpected color in (%v, %v); got=%v, want=%v", x, y, got, MasterPalette[want])
+	}
+}
+
+func TestPPU_RenderBackground(t *testing.T) {
+	ppu, bus := newRenderTestPPU()
+
+	// the top left 2x2 tiles use the palette 1
+	bus[NametablesStart+attributeTableOffset] = 0x01
+	ppu.WriteRegister(MaskRegister, MaskBackground|MaskBackgroundLeft)
+	renderFrame(t, ppu, 0, 0)
+
+	checkPixel(t, ppu, 0, 0, 0x16)
+	checkPixel(t, ppu, 15, 15, 0x16)
+	checkPixel(t, ppu, 16, 0, 0x30)
+	checkPixel(t, ppu, 255, 239, 0x30)
+
+	// the left 8 pixels are hidden
+	ppu.WriteRegister(MaskRegister, MaskBackground)
+	renderFrame(t, ppu, 0, 0)
+
+	checkPixel(t, ppu, 7, 0, 0x0F)
+	checkPixel(t, ppu, 8, 0, 0x16)
+
+	// grayscale
+	ppu.WriteRegister(MaskRegister, MaskBackground|MaskBackgroundLeft|MaskGrayscale)
+	renderFrame(t, ppu, 0, 0)
+
+	checkPixel(t, ppu, 0, 0, 0x10)
+}
+
+func TestPPU_RenderScroll(t *testing.T) {
+	ppu, bus := newRenderTestPPU()
+
+	bus[NametablesStart+attributeTableOffset] = 0x01
+	ppu.WriteRegister(MaskRegister, MaskBackground|MaskBackgroundLeft)
+	renderFrame(t, ppu, 12, 4)
+
+	checkPixel(t, ppu, 0, 0, 0x16)
+	checkPixel(t, ppu, 3, 11, 0x16)
+	checkPixel(t, ppu, 4, 0, 0x30)
+	checkPixel(t, ppu, 0, 12, 0x30)
+}
+
+func TestPPU_RenderSplitControl(t *testing.T) {
+	ppu, bus := newRenderTestPPU()
+
+	// the nametable 1 uses the palette 1
+	for i := 0; i < nametableSize-attributeTableOffset; i++ {
+		bus[NametablesStart+nametableSize+attributeTableOffset+i] = 0x55
+	}
+
+	ppu.WriteRegister(MaskRegister, MaskBackground|MaskBackgroundLeft)
+	renderFrame(t, ppu, 0, 0)
+	clockUntil(t, ppu, 0, PreRenderScanline)
+
+	// only the horizontal nametable is copied to v in the next scanline;
+	// the vertical one waits for the next frame
+	clockUntil(t, ppu, 100, 100)
+	ppu.WriteRegister(ControlRegister, 0x03)
+	clockUntil(t, ppu, 2, VBlankScanline)
+
+	checkPixel(t, ppu, 0, 100, 0x30)
+	checkPixel(t, ppu, 255, 100, 0x30)
+	checkPixel(t, ppu, 0, 101, 0x16)
+	checkPixel(t, ppu, 255, 239, 0x16)
+}
+
+func TestPPU_RenderSplitAddress(t *testing.T) {
+	ppu, bus := newRenderTestPPU()
+
+	// the top left 2x2 tiles of the nametable 1 use the palette 1
+	bus[NametablesStart+nametableSize+attributeTableOffset] = 0x01
+
+	ppu.WriteRegister(MaskRegister, MaskBackground|MaskBackgroundLeft)
+	renderFrame(t, ppu, 0, 0)
+	clockUntil(t, ppu, 0, PreRenderScanline)
+
+	// PPUADDR changes v right away, and the next scanline starts from the
+	// top of the nametable 1. The bits 12-13 of the address are fine Y, so
+	// it's $0400 instead of $2400.
+	clockUntil(t, ppu, 260, 50)
+	ppu.WriteRegister(AddressRegister, 0x04)
+	ppu.WriteRegister(AddressRegister, 0x00)
+	clockUntil(t, ppu, 2, VBlankScanline)
+
+	checkPixel(t, ppu, 0, 50, 0x30)
+	checkPixel(t, ppu, 0, 51, 0x16)
+	checkPixel(t, ppu, 15, 66, 0x16)
+	checkPixel(t, ppu, 16, 51, 0x30)
+	checkPixel(t, ppu, 0, 67, 0x30)
+}
+
+func TestPPU_ScrollIncrement(t *testing.T) {
+	tests := []struct {
+		name string
+		v    uint16
+		// v after the increments at the end of the scanline
+		want uint16
+	}{
+		{"fine Y", 0x0000, 0x1000},
+		{"coarse Y", 0x7000, 0x0020},
+		{"next nametable", 0x73A0, 0x0800},
+		{"attribute rows", 0x73E0, 0x0000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ppu := New(new(testBus))
+			ppu.t = test.v & horizontalMask
+			ppu.v = test.v
+
+			ppu.incrementY()
+			ppu.v = ppu.v&^horizontalMask | ppu.t&horizontalMask
+
+			if ppu.v != test.want {
+				t.Errorf("unexpected VRAM address; got=%04X, want=%04X", ppu.v, test.want)
+			}
+		})
+	}
+
+	ppu := New(new(testBus))
+	ppu.v = 0x001F
+	ppu.incrementX()
+
+	if want := uint16(0x0400); ppu.v != want {
+		t.Errorf("coarse X should wrap to the next nametable; got=%04X, want=%04X", ppu.v, want)
+	}
+}
+
+func TestPPU_RenderDisabled(t *testing.T) {
+	ppu, _ := newRenderTestPPU()
+
+	ppu.WriteRegister(AddressRegister, 0x20)
+	ppu.WriteRegister(AddressRegister, 0x00)
+	renderFrame(t, ppu, 0, 0)
+
+	checkPixel(t, ppu, 100, 100, 0x0F)
+
+	// the color pointed by the VRAM address replaces the backdrop
+	ppu.WriteRegister(AddressRegister, 0x3F)
+	ppu.WriteRegister(AddressRegister, 0x01)
+	renderFrame(t, ppu, 0, 0)
+
+	checkPixel(t, ppu, 100, 100, 0x30)
+}
+
+func TestPPU_RenderSprites(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes uint8
+		// color in the column 20 of the scanline 10
+		want uint8
+	}{
+		{"in front", 0x01, 0x27},
+		{"behind", 0x01 | spriteAttributeBehind, 0x30},
+	}
+
+	for _, test := range tests {
+		ppu, _ := newRenderTestPPU()
+
+		// drawn from the scanline 10
+		copy(ppu.OAM[:], []uint8{9, 0x02, test.attributes, 20})
+		ppu.WriteRegister(MaskRegister, MaskBackground|MaskSprites|MaskBackgroundLeft|MaskSpritesLeft)
+		renderFrame(t, ppu, 0, 0)
+
+		checkPixel(t, ppu, 20, 10, test.want)
+		checkPixel(t, ppu, 27, 17, test.want)
+		checkPixel(t, ppu, 20, 9, 0x30)
+		checkPixel(t, ppu, 28, 10, 0x30)
+
+		if ppu.status&StatusSprite0Hit == 0x00 {
+			t.Errorf("sprite 0 hit should be set with the sprite %v", test.name)
+		}
+	}
+}
+
+func TestPPU_RenderSpriteFlip(t *testing.T) {
+	ppu, bus := newRenderTestPPU()
+
+	// tile 3: only the top left pixel has a color
+	bus[0x0030] = 0x80
+	bus[0x0038] = 0x80
+
+	copy(ppu.OAM[:], []uint8{
+		9, 0x03, 0x00, 20,
+		9, 0x03, spriteAttributeFlipH | spriteAttributeFlipV, 40,
+	})
+	writePalette(ppu, 0x13, 0x2A)
+	ppu.WriteRegister(MaskRegister, MaskSprites)
+	renderFrame(t, ppu, 0, 0)
+
+	checkPixel(t, ppu, 20, 10, 0x2A)
+	checkPixel(t, ppu, 47, 17, 0x2A)
+	checkPixel(t, ppu, 40, 10, 0x0F)
+
+	if ppu.status&StatusSprite0Hit != 0x00 {
+		t.Error("sprite 0 hit should not be set without the background")
+	}
+}
+
+func TestPPU_RenderSpriteOverflow(t *testing.T) {
+	ppu, _ := newRenderTestPPU()
+
+	// 9 sprites in the scanline 100
+	for i := 0; i < 9; i++ {
+		copy(ppu.OAM[4*(i+1):], []uint8{100, 0x02, 0x01, uint8(8 * i)})
+	}
+
+	ppu.WriteRegister(MaskRegister, MaskSprites|MaskSpritesLeft)
+	renderFrame(t, ppu, 0, 0)
+
+	if ppu.status&StatusSpriteOverflow == 0x00 {
+		t.Error("sprite overflow should be set with 9 sprites in a scanline")
+	}
+
+	// only the first 8 are drawn
+	checkPixel(t, ppu, 0, 101, 0x27)
+	checkPixel(t, ppu, 63, 101, 0x27)
+	checkPixel(t, ppu, 64, 101, 0x0F)
+}
+
+func TestPPU_RenderEmphasis(t *testing.T) {
+	ppu, _ := newRenderTestPPU()
+
+	// emphasize red
+	ppu.WriteRegister(MaskRegister, MaskBackground|MaskBackgroundLeft|0x20)
+	renderFrame(t, ppu, 0, 0)
+
+	white := MasterPalette[0x30]
+	want := color.RGBA{white.R, white.G - white.G/3, white.B - white.B/3, 0xFF}
+
+	if got := ppu.Frame().RGBAAt(0, 0); got != want {
+		t.Errorf("unexpected emphasized color; got=%v, want=%v", got, want)
+	}
+}
@@ -0,0 +1,106 @@
+package nes
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SaveExtension is the extension of the files with battery saves.
+const SaveExtension = ".sav"
+
+// SavePath returns the path of the battery save of the ROM in romPath. The
+// save is kept next to the ROM, unless dir is given.
+func SavePath(romPath string, dir string) string {
+	savePath := strings.TrimSuffix(romPath, filepath.Ext(romPath)) + SaveExtension
+
+	if dir == "" {
+		return savePath
+	}
+
+	return filepath.Join(dir, filepath.Base(savePath))
+}
+
+// SaveFile keeps a battery save in a file. It can be used as NES.Save: the
+// save is read from the file, which may not exist yet, and every write
+// replaces the whole file. The file is read again after a write or a Seek to
+// its start, which PowerOn does before loading the save.
+type SaveFile struct {
+	Path string
+
+	data *bytes.Reader
+}
+
+func NewSaveFile(path string) *SaveFile {
+	return &SaveFile{Path: path}
+}
+
+// Read reads the save as it was in the file when the first Read since the
+// last write or rewind was called.
+func (f *SaveFile) Read(p []byte) (int, error) {
+	if err := f.load(); err != nil {
+		return 0, err
+	}
+
+	return f.data.Read(p)
+}
+
+// Seek moves the position of the next Read. Seeking to the start makes it
+// read the file again.
+func (f *SaveFile) Seek(offset int64, whence int) (int64, error) {
+	if offset == 0 && whence == io.SeekStart {
+		f.data = nil
+		return 0, nil
+	}
+
+	if err := f.load(); err != nil {
+		return 0, err
+	}
+
+	return f.data.Seek(offset, whence)
+}
+
+func (f *SaveFile) load() error {
+	if f.data != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(f.Path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	f.data = bytes.NewReader(data)
+
+	return nil
+}
+
+// Write replaces the save with p. The file is replaced atomically, so it's
+// never left with a partial save.
+func (f *SaveFile) Write(p []byte) (int, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".*.tmp")
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp.Name())
+
+	n, err := tmp.Write(p)
+	if err != nil {
+		tmp.Close()
+		return n, err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return n, err
+	}
+
+	if err := os.Rename(tmp.Name(), f.Path); err != nil {
+		return n, err
+	}
+
+	f.data = nil
+
+	return n, nil
+}
@@ -0,0 +1,207 @@
+package nes
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSavePath(t *testing.T) {
+	tests := []struct {
+		romPath string
+		dir     string
+		want    string
+	}{
+		{"games/zelda.nes", "", "games/zelda.sav"},
+		{"games/zelda.nes", "saves", "saves/zelda.sav"},
+		{"zelda", "", "zelda.sav"},
+	}
+
+	for _, test := range tests {
+		if path := SavePath(test.romPath, test.dir); path != filepath.FromSlash(test.want) {
+			t.Errorf("unexpected save path of %v in %q; got=%v, want=%v", test.romPath, test.dir, path, test.want)
+		}
+	}
+}
+
+func TestSaveFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "game.sav")
+
+	var data [4]uint8
+
+	if n, err := NewSaveFile(path).Read(data[:]); n != 0 || err != io.EOF {
+		t.Errorf("a missing save should be empty; got n=%v, err=%v", n, err)
+	}
+
+	save := NewSaveFile(path)
+
+	for _, content := range [][]uint8{{0x01, 0x02, 0x03}, {0x04, 0x05}} {
+		if _, err := save.Write(content); err != nil {
+			t.Fatal(err)
+		}
+
+		written, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !bytes.Equal(written, content) {
+			t.Errorf("the save should be replaced on each write; got=%v, want=%v", written, content)
+		}
+	}
+
+	if n, err := io.ReadFull(NewSaveFile(path), data[:]); n != 2 || err != io.ErrUnexpectedEOF || !bytes.Equal(data[:n], []uint8{0x04, 0x05}) {
+		t.Errorf("unexpected save read; got=%v (n=%v, err=%v)", data[:n], n, err)
+	}
+}
+
+// testSave is a save kept in memory, which counts its writes.
+type testSave struct {
+	bytes.Buffer
+	writes int
+}
+
+func (s *testSave) Write(p []byte) (int, error) {
+	s.writes++
+	s.Reset()
+	return s.Buffer.Write(p)
+}
+
+// newBatteryTestNES creates a system with a game that has a battery, whose
+// PRG ROM is filled with NOPs (including the reset vector, 0xEAEA).
+func newBatteryTestNES(t *testing.T, save *testSave, saveInterval uint64) *NES {
+	game := newTestGame(t, 1, 0)
+	// battery
+	game.Header[6] = 0x02
+
+	for i := range game.PRG {
+		game.PRG[i] = 0xEA
+	}
+
+	system := &NES{
+		Save:         save,
+		SaveInterval: saveInterval,
+	}
+
+	if _, err := system.PowerOn(*game); err != nil {
+		t.Fatal(err)
+	}
+
+	return system
+}
+
+func TestNES_Save(t *testing.T) {
+	save := &testSave{}
+	save.Buffer.Write([]uint8{0x12, 0x34})
+	save.Buffer.Write(make([]uint8, PRGRAMSize-2))
+
+	system := newBatteryTestNES(t, save, 0)
+
+	if value := system.ReadWord(PRGRAMStart); value != 0x3412 {
+		t.Errorf("unexpected PRG RAM loaded from the save; got=%04X, want=%04X", value, 0x3412)
+	}
+
+	if err := system.FlushSave(); err != nil {
+		t.Fatal(err)
+	}
+	if save.writes != 0 {
+		t.Errorf("the save should not be written before the PRG RAM changes; got=%v writes", save.writes)
+	}
+
+	system.WriteByte(PRGRAMStart+1, 0x56)
+
+	if err := system.FlushSave(); err != nil {
+		t.Fatal(err)
+	}
+	if save.writes != 1 {
+		t.Errorf("the save should be written after the PRG RAM changes; got=%v writes", save.writes)
+	}
+	if saved := save.Bytes(); len(saved) != PRGRAMSize || saved[0] != 0x12 || saved[1] != 0x56 {
+		t.Errorf("unexpected save written; got=%v bytes starting with %v", len(saved), saved[:2])
+	}
+}
+
+func TestNES_SaveTruncated(t *testing.T) {
+	save := &testSave{}
+	save.Buffer.Write([]uint8{0x12, 0x34})
+
+	game := newTestGame(t, 1, 0)
+	// battery
+	game.Header[6] = 0x02
+
+	system := NES{Save: save}
+
+	_, err := system.PowerOn(*game)
+	if want := (TruncatedSaveError{PRGRAMSize, 2}); err != want {
+		t.Errorf("unexpected error with a truncated save; got=%v, want=%v", err, want)
+	}
+	if save.writes != 0 {
+		t.Errorf("a truncated save should not be replaced; got=%v writes", save.writes)
+	}
+}
+
+func TestNES_SaveFilePowerOn(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "game.sav")
+
+	data := make([]uint8, PRGRAMSize)
+	data[0] = 0x12
+
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	game := newTestGame(t, 1, 0)
+	// battery
+	game.Header[6] = 0x02
+
+	system := NES{Save: NewSaveFile(path)}
+
+	// the save is read again on each power on, including what was flushed
+	// since the last one
+	for i, want := range []uint8{0x12, 0x12, 0x34} {
+		if _, err := system.PowerOn(*game); err != nil {
+			t.Fatal(err)
+		}
+
+		if value := system.ReadByte(PRGRAMStart); value != want {
+			t.Errorf("unexpected PRG RAM loaded by the power on #%v; got=%02X, want=%02X", i+1, value, want)
+		}
+
+		if i == 1 {
+			system.WriteByte(PRGRAMStart, 0x34)
+
+			if err := system.FlushSave(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestNES_SaveInterval(t *testing.T) {
+	save := &testSave{}
+	system := newBatteryTestNES(t, save, 100)
+
+	system.WriteByte(PRGRAMStart, 0x12)
+
+	for system.Cycles() < 98 {
+		if _, err := system.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if save.writes != 0 {
+		t.Errorf("the save should not be written before the interval; got=%v writes", save.writes)
+	}
+
+	for system.Cycles() < 102 {
+		if _, err := system.Step(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if save.writes != 1 {
+		t.Errorf("the save should be written after the interval; got=%v writes", save.writes)
+	}
+}